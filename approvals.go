@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	approvalIdHeader = "approval-id"
+
+	// approvalExecuteTimeout is how long the call of an approval may run, an
+	// approval executing longer was interrupted.
+	approvalExecuteTimeout = 5 * time.Minute
+
+	// approvalPendingReason is the reason in the ErrorInfo of the status of a
+	// call kept as a pending approval.
+	approvalPendingReason = "APPROVAL_PENDING"
+)
+
+// approvalRpcs decide on approvals, so they can't need one themselves.
+var approvalRpcs = []string{"ListPendingApprovals", "Approve", "Reject"}
+
+// ApprovalPolicy lists the rpcs a second operator has to approve and how long
+// a call waits for the approval.
+type ApprovalPolicy struct {
+	Methods map[string]bool
+	TTL     time.Duration
+}
+
+// parseApprovalMethods parses a comma separated list of rpc names.
+func parseApprovalMethods(list string) (map[string]bool, error) {
+	methods := make(map[string]bool)
+	service := pb.File_pb_service_proto.Services().ByName("Gandalf")
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		method := service.Methods().ByName(protoreflect.Name(name))
+		if method == nil || method.IsStreamingServer() {
+			return nil, errors.New(fmt.Sprintf("unknown unary method %s", name))
+		}
+		for _, rpc := range approvalRpcs {
+			if name == rpc {
+				return nil, errors.New(fmt.Sprintf("method %s can't need an approval", name))
+			}
+		}
+		methods[name] = true
+	}
+	return methods, nil
+}
+
+// ApprovalInterceptor keeps calls of the rpcs of the policy as pending
+// approvals instead of running them. The call fails with FailedPrecondition
+// and the id of the approval in the approval-id header and the message, the
+// rpc runs once another operator approves it.
+func (s *Server) ApprovalInterceptor(policy ApprovalPolicy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		method := info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]
+		if !policy.Methods[method] {
+			return handler(ctx, req)
+		}
+
+		r, ok := req.(userRequest)
+		message, isMessage := req.(proto.Message)
+		if !ok || !isMessage {
+			return handler(ctx, req)
+		}
+		if err := s.checkUserOperator(r.GetUserId()); err != nil {
+			return nil, err
+		}
+
+		body, err := proto.Marshal(message)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		event := ApprovalEvent{At: now, UserId: r.GetUserId(), Action: approvalRequested}
+		approval := &Approval{
+			Id:          newApprovalId(),
+			Method:      method,
+			RequestType: string(message.ProtoReflect().Descriptor().FullName()),
+			Request:     body,
+			RequestedBy: r.GetUserId(),
+			Status:      approvalPending,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			ExpiresAt:   now.Add(policy.TTL),
+			Events:      []ApprovalEvent{event},
+		}
+		if err := s.storage.CreateApproval(ctx, approval); err != nil {
+			return nil, err
+		}
+		s.recordApproval(ctx, approval, event)
+
+		setResponseHeader(ctx, approvalIdHeader, approval.Id)
+		return nil, errApprovalPending(approval)
+	}
+}
+
+func errApprovalPending(approval *Approval) error {
+	st := status.New(codes.FailedPrecondition, fmt.Sprintf(
+		"%s needs the approval of another operator, approval %s is pending until %s",
+		approval.Method, approval.Id, approval.ExpiresAt.UTC().Format(time.RFC3339),
+	))
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   approvalPendingReason,
+		Domain:   "gandalf",
+		Metadata: map[string]string{"approval_id": approval.Id},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// isApprovalPending tells whether the call failed because it is kept as a
+// pending approval.
+func isApprovalPending(err error) bool {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Reason == approvalPendingReason {
+			return true
+		}
+	}
+	return false
+}
+
+func newApprovalId() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// ReconcileApprovals fails the approvals executing longer than
+// approvalExecuteTimeout, the service stopped during their call. Whether the
+// call took effect is unknown, so the operators have to check it.
+func (s *Server) ReconcileApprovals(ctx context.Context) error {
+	approvals, err := s.storage.GetStaleApprovals(ctx, approvalExecuting, time.Now().Add(-approvalExecuteTimeout))
+	if err != nil {
+		return err
+	}
+
+	for _, approval := range approvals {
+		event := ApprovalEvent{
+			At:      time.Now(),
+			Action:  approvalFailed,
+			Message: "interrupted while executing, the call may have taken effect",
+		}
+		updated, err := s.storage.UpdateApprovalStatus(ctx, approval.Id, approvalExecuting, approvalFailed, event)
+		if err != nil {
+			return err
+		}
+		if updated {
+			s.recordApproval(ctx, approval, event)
+		}
+	}
+	return nil
+}
+
+// recordApproval logs a step of an approval and publishes it, the step itself
+// is stored in the events of the approval.
+func (s *Server) recordApproval(ctx context.Context, approval *Approval, event ApprovalEvent) {
+	fields := []interface{}{
+		"approval_id", approval.Id,
+		"method", approval.Method,
+		"action", event.Action,
+		"user_id", event.UserId,
+		"requested_by", approval.RequestedBy,
+	}
+	if event.Message != "" {
+		fields = append(fields, "message", event.Message)
+	}
+	loggerFromContext(ctx, s.logger).Infow("approval "+event.Action, fields...)
+
+	message := fmt.Sprintf("approval %s of %s %s", approval.Id, approval.Method, event.Action)
+	if event.UserId != 0 {
+		message += fmt.Sprintf(" by %d", event.UserId)
+	}
+	if event.Message != "" {
+		message += ": " + event.Message
+	}
+	s.notifier.Publish(Event{
+		Type:    EventApproval,
+		Message: message,
+		Data: map[string]string{
+			"approval_id": approval.Id,
+			"method":      approval.Method,
+			"action":      event.Action,
+		},
+	})
+}
+
+// approvalRequest restores the request of the call kept by the approval.
+func approvalRequest(approval *Approval) (proto.Message, error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(approval.RequestType))
+	if err != nil {
+		return nil, err
+	}
+	req := messageType.New().Interface()
+	if err := proto.Unmarshal(approval.Request, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func approvalToPb(approval *Approval) *pb.Approval {
+	var request string
+	if req, err := approvalRequest(approval); err == nil {
+		if data, err := protojson.Marshal(req); err == nil {
+			request = string(data)
+		}
+	}
+
+	events := make([]*pb.ApprovalEvent, 0, len(approval.Events))
+	for _, event := range approval.Events {
+		events = append(events, &pb.ApprovalEvent{
+			Time:    timestamppb.New(event.At),
+			UserId:  event.UserId,
+			Action:  event.Action,
+			Message: event.Message,
+		})
+	}
+
+	return &pb.Approval{
+		ApprovalId:  approval.Id,
+		Method:      approval.Method,
+		Request:     request,
+		RequestedBy: approval.RequestedBy,
+		Status:      approval.Status,
+		CreatedAt:   timestamppb.New(approval.CreatedAt),
+		ExpiresAt:   timestamppb.New(approval.ExpiresAt),
+		Events:      events,
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// BalanceSnapshotter records the balances of all symbols and their value in
+// the quote currency every interval, GetBalanceHistory reads them back.
+type BalanceSnapshotter struct {
+	logger   *zap.SugaredLogger
+	storage  *Storage
+	prices   PriceSource
+	quote    string
+	interval time.Duration
+}
+
+func NewBalanceSnapshotter(
+	logger *zap.SugaredLogger,
+	storage *Storage,
+	prices PriceSource,
+	quote string,
+	interval time.Duration,
+) *BalanceSnapshotter {
+	return &BalanceSnapshotter{
+		logger:   logger,
+		storage:  storage,
+		prices:   prices,
+		quote:    quote,
+		interval: interval,
+	}
+}
+
+// Run takes a snapshot every interval until ctx is done.
+func (s *BalanceSnapshotter) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.snapshot(ctx); err != nil {
+				s.logger.Errorf("cannot snapshot balances: %v", err)
+			}
+		}
+	}
+}
+
+func (s *BalanceSnapshotter) snapshot(ctx context.Context) error {
+	tradingSymbols, err := s.storage.GetTradingSymbols(ctx)
+	if err != nil {
+		return err
+	}
+
+	at := time.Now()
+	for _, symbol := range tradingSymbols {
+		value, err := ConvertAmount(ctx, s.prices, symbol.Symbol, symbol.Balance, s.quote)
+		if err != nil {
+			s.logger.Warnf("cannot value the balance of %s in %s: %v", symbol.Symbol, s.quote, err)
+			continue
+		}
+		if err := s.storage.AddBalanceSnapshot(ctx, symbol, s.quote, value, at); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// balancePoint is the balance of a symbol downsampled to an interval.
+type balancePoint struct {
+	start    time.Time
+	balance  decimal.Decimal
+	value    decimal.Decimal
+	minValue decimal.Decimal
+	maxValue decimal.Decimal
+}
+
+// balanceDownsampler merges the buckets of the symbols into points of the
+// interval, a day starts at midnight in the location. Buckets must be
+// added in the order of their start.
+type balanceDownsampler struct {
+	interval pb.BalanceHistoryRequest_Interval
+	location *time.Location
+	symbols  []string
+	paper    map[string]bool
+	points   map[string][]*balancePoint
+}
+
+func newBalanceDownsampler(interval pb.BalanceHistoryRequest_Interval, location *time.Location) *balanceDownsampler {
+	return &balanceDownsampler{
+		interval: interval,
+		location: location,
+		paper:    make(map[string]bool),
+		points:   make(map[string][]*balancePoint),
+	}
+}
+
+func (d *balanceDownsampler) intervalStart(t time.Time) time.Time {
+	if d.interval == pb.BalanceHistoryRequest_DAY {
+		t = t.In(d.location)
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, d.location)
+	}
+	return t.Truncate(time.Hour)
+}
+
+func (d *balanceDownsampler) add(bucket *BalanceBucket) {
+	points, ok := d.points[bucket.Symbol]
+	if !ok {
+		d.symbols = append(d.symbols, bucket.Symbol)
+	}
+	d.paper[bucket.Symbol] = bucket.Paper
+
+	start := d.intervalStart(bucket.Start)
+	if n := len(points); n > 0 && points[n-1].start.Equal(start) {
+		point := points[n-1]
+		point.balance, point.value = bucket.Balance, bucket.Value
+		point.minValue = decimal.Min(point.minValue, bucket.MinValue)
+		point.maxValue = decimal.Max(point.maxValue, bucket.MaxValue)
+		return
+	}
+
+	d.points[bucket.Symbol] = append(points, &balancePoint{
+		start:    start,
+		balance:  bucket.Balance,
+		value:    bucket.Value,
+		minValue: bucket.MinValue,
+		maxValue: bucket.MaxValue,
+	})
+}
+
+// history returns the points of every symbol and the totals of the real ones.
+func (d *balanceDownsampler) history() ([]*pb.BalanceHistory, []*pb.BalancePoint) {
+	sort.Strings(d.symbols)
+
+	totals := make(map[int64]decimal.Decimal)
+	histories := make([]*pb.BalanceHistory, 0, len(d.symbols))
+	for _, symbol := range d.symbols {
+		history := &pb.BalanceHistory{Symbol: symbol, Paper: d.paper[symbol]}
+		for _, point := range d.points[symbol] {
+			history.Points = append(history.Points, &pb.BalancePoint{
+				Time:                 timestamppb.New(point.start),
+				BalanceDecimal:       point.balance.String(),
+				QuoteValueDecimal:    point.value.String(),
+				MinQuoteValueDecimal: point.minValue.String(),
+				MaxQuoteValueDecimal: point.maxValue.String(),
+			})
+			if !history.Paper {
+				totals[point.start.Unix()] = totals[point.start.Unix()].Add(point.value)
+			}
+		}
+		histories = append(histories, history)
+	}
+
+	starts := make([]int64, 0, len(totals))
+	for start := range totals {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+
+	points := make([]*pb.BalancePoint, 0, len(starts))
+	for _, start := range starts {
+		points = append(points, &pb.BalancePoint{
+			Time:              timestamppb.New(time.Unix(start, 0)),
+			QuoteValueDecimal: totals[start].String(),
+		})
+	}
+
+	return histories, points
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"github.com/shopspring/decimal"
+)
+
+func TestBalanceDownsamplerDays(t *testing.T) {
+	tests := []struct {
+		timezone string
+		midnight time.Time // a local midnight in UTC
+	}{
+		{"UTC", time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC)},
+		{"America/New_York", time.Date(2021, 6, 2, 4, 0, 0, 0, time.UTC)},
+		{"Asia/Kolkata", time.Date(2021, 6, 1, 18, 30, 0, 0, time.UTC)},
+		{"Asia/Kathmandu", time.Date(2021, 6, 1, 18, 15, 0, 0, time.UTC)},
+		{"Australia/Adelaide", time.Date(2021, 6, 1, 14, 30, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		location, err := time.LoadLocation(test.timezone)
+		if err != nil {
+			t.Skipf("no timezone data for %s: %v", test.timezone, err)
+		}
+
+		// the bucket before midnight closes the first day, the one at
+		// midnight opens the second
+		downsampler := newBalanceDownsampler(pb.BalanceHistoryRequest_DAY, location)
+		for i, value := range []int64{1, 2, 3, 4} {
+			start := test.midnight.Add(time.Duration(i-2) * balanceBucketSize)
+			downsampler.add(&BalanceBucket{
+				Symbol:   "adausdt",
+				Start:    start,
+				Balance:  decimal.NewFromInt(value),
+				Value:    decimal.NewFromInt(value),
+				MinValue: decimal.NewFromInt(value),
+				MaxValue: decimal.NewFromInt(value),
+			})
+		}
+
+		histories, totals := downsampler.history()
+		if len(histories) != 1 || len(histories[0].Points) != 2 {
+			t.Fatalf("%s: histories %v, want 1 symbol with 2 points", test.timezone, histories)
+		}
+		first, second := histories[0].Points[0], histories[0].Points[1]
+		if !first.Time.AsTime().Equal(test.midnight.AddDate(0, 0, -1)) || !second.Time.AsTime().Equal(test.midnight) {
+			t.Errorf("%s: points at %v and %v, want the days starting at %v", test.timezone, first.Time.AsTime(), second.Time.AsTime(), test.midnight)
+		}
+		if first.QuoteValueDecimal != "2" || first.MinQuoteValueDecimal != "1" || first.MaxQuoteValueDecimal != "2" {
+			t.Errorf("%s: first day %v, want value 2 of 1..2", test.timezone, first)
+		}
+		if second.QuoteValueDecimal != "4" || second.MinQuoteValueDecimal != "3" || second.MaxQuoteValueDecimal != "4" {
+			t.Errorf("%s: second day %v, want value 4 of 3..4", test.timezone, second)
+		}
+		if len(totals) != 2 || totals[1].QuoteValueDecimal != "4" {
+			t.Errorf("%s: totals %v, want 2 days ending at 4", test.timezone, totals)
+		}
+	}
+}
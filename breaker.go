@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// CircuitBreaker suspends a symbol when its loss over the rolling window
+// exceeds the max drawdown of the symbol, and halts trading when the loss of
+// all real symbols exceeds the portfolio threshold. The loss is the result of
+// the deals closed within the window plus the current result of open deals,
+// both in the quote currency, so the portfolio sum assumes a common quote.
+// Losses before the last resume of a symbol, or of trading, are not counted,
+// so a resume isn't undone by the next check.
+type CircuitBreaker struct {
+	logger               *zap.SugaredLogger
+	storage              *Storage
+	prices               PriceSource
+	server               *Server
+	window               time.Duration
+	interval             time.Duration
+	portfolioMaxDrawdown decimal.Decimal
+}
+
+func NewCircuitBreaker(
+	logger *zap.SugaredLogger,
+	storage *Storage,
+	prices PriceSource,
+	server *Server,
+	window time.Duration,
+	interval time.Duration,
+	portfolioMaxDrawdown decimal.Decimal,
+) *CircuitBreaker {
+	return &CircuitBreaker{
+		logger:               logger,
+		storage:              storage,
+		prices:               prices,
+		server:               server,
+		window:               window,
+		interval:             interval,
+		portfolioMaxDrawdown: portfolioMaxDrawdown,
+	}
+}
+
+// Run checks the drawdowns every interval until ctx is done.
+func (b *CircuitBreaker) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := b.check(ctx); err != nil {
+				b.logger.Errorf("cannot check drawdowns: %v", err)
+			}
+		}
+	}
+}
+
+func (b *CircuitBreaker) check(ctx context.Context) error {
+	halt, err := b.storage.GetHalt(ctx)
+	if err != nil {
+		return err
+	}
+	if halt != nil {
+		return nil
+	}
+
+	resume, err := b.storage.GetHaltResume(ctx)
+	if err != nil {
+		return err
+	}
+	tradingSymbols, err := b.storage.GetTradingSymbols(ctx)
+	if err != nil {
+		return err
+	}
+	windowStart := time.Now().Add(-b.window)
+
+	// losses before a resume have been seen by an operator, so they count
+	// neither for the symbol nor, after EmergencyResume, for the portfolio
+	portfolioStart := windowStart
+	if resume != nil && resume.ResumedAt.After(portfolioStart) {
+		portfolioStart = resume.ResumedAt
+	}
+	symbolStarts := make(map[string]time.Time)
+	portfolioStarts := make(map[string]time.Time)
+	for _, symbol := range tradingSymbols {
+		symbolStarts[symbol.Symbol] = windowStart
+		if symbol.ResumedAt.After(windowStart) {
+			symbolStarts[symbol.Symbol] = symbol.ResumedAt
+		}
+		if !symbol.Paper {
+			portfolioStarts[symbol.Symbol] = portfolioStart
+		}
+	}
+
+	// open deals count from their opening, the deals opened before a resume
+	// count once they are closed
+	symbolSums, err := b.storage.SumDeals(ctx, symbolStarts)
+	if err != nil {
+		return err
+	}
+	portfolioSums, err := b.storage.SumDeals(ctx, portfolioStarts)
+	if err != nil {
+		return err
+	}
+
+	results := make(map[string]decimal.Decimal)
+	portfolio := decimal.Zero
+	err = b.storage.EachClosedDeal(ctx, nil, windowStart, time.Time{}, func(deal *ClosedDeal) error {
+		if start, ok := symbolStarts[deal.Symbol]; ok && deal.ClosedAt.After(start) {
+			results[deal.Symbol] = results[deal.Symbol].Add(deal.DeltaAmount)
+		}
+		if start, ok := portfolioStarts[deal.Symbol]; ok && deal.ClosedAt.After(start) {
+			portfolio = portfolio.Add(deal.DeltaAmount)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	prices := make(map[string]decimal.Decimal)
+	for symbol, sums := range symbolSums {
+		results[symbol] = results[symbol].Add(b.unrealized(ctx, sums, prices))
+	}
+	for _, sums := range portfolioSums {
+		portfolio = portfolio.Add(b.unrealized(ctx, sums, prices))
+	}
+
+	if b.portfolioMaxDrawdown.IsPositive() && portfolio.Neg().GreaterThanOrEqual(b.portfolioMaxDrawdown) {
+		reason := fmt.Sprintf("portfolio drawdown %s over %s reached %s", portfolio.Neg(), b.window, b.portfolioMaxDrawdown)
+		return b.server.halt(ctx, 0, reason, false)
+	}
+
+	for _, symbol := range tradingSymbols {
+		drawdown := results[symbol.Symbol].Neg()
+		if symbol.Status != pb.TradingSymbol_ACTIVE || !symbol.MaxDrawdown.IsPositive() || drawdown.LessThan(symbol.MaxDrawdown) {
+			continue
+		}
+
+		reason := fmt.Sprintf("drawdown %s over %s reached %s", drawdown, b.window, symbol.MaxDrawdown)
+		if err := b.server.suspendSymbol(ctx, symbol, reason); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unrealized returns the result of the open deals of a symbol at the current
+// price. Deals without an open price, and deals of symbols without a price,
+// keep the delta stored with the deal.
+func (b *CircuitBreaker) unrealized(ctx context.Context, sums *DealSums, prices map[string]decimal.Decimal) decimal.Decimal {
+	if !sums.OpenAmount.IsPositive() {
+		return sums.DeltaAmount
+	}
+
+	price, ok := prices[sums.Symbol]
+	if !ok {
+		var err error
+		if price, err = b.prices.GetPrice(ctx, sums.Symbol); err != nil {
+			b.logger.Warnf("cannot get price of %s for the drawdown check: %v", sums.Symbol, err)
+		}
+		prices[sums.Symbol] = price
+	}
+	if !price.IsPositive() {
+		return sums.DeltaAmount
+	}
+
+	return price.Mul(sums.OpenAmount).Sub(sums.OpenCost).Add(sums.DeltaAmount.Sub(sums.OpenDeltaAmount))
+}
@@ -0,0 +1,793 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ctl struct {
+	client pb.GandalfClient
+	config *ctlConfig
+	out    io.Writer
+	in     *bufio.Reader
+	yes    bool
+}
+
+var (
+	errUsage   = errors.New("usage")
+	errAborted = errors.New("aborted")
+)
+
+func (c *ctl) run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "symbols":
+		return c.symbols(ctx, args[1:])
+	case "balances":
+		return c.balances(ctx, args[1:])
+	case "limits":
+		return c.limits(ctx, args[1:])
+	case "deals":
+		return c.deals(ctx, args[1:])
+	case "config":
+		return c.symbolConfig(ctx, args[1:])
+	case "predictions":
+		return c.predictions(ctx, args[1:])
+	case "schedules":
+		return c.schedules(ctx, args[1:])
+	case "export":
+		return c.export(ctx, args[1:])
+	case "approvals":
+		return c.approvals(ctx, args[1:])
+	case "halt":
+		return c.halt(ctx, args[1:])
+	case "unhalt":
+		if len(args) != 1 {
+			return errUsage
+		}
+		if _, err := c.client.EmergencyResume(ctx, &pb.EmptyRequest{UserId: c.config.UserId}); err != nil {
+			return err
+		}
+		return c.done(&pb.EmptyResponse{}, "trading resumed")
+	default:
+		return errUsage
+	}
+}
+
+func (c *ctl) symbols(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	if args[0] == "list" {
+		resp := &pb.TradingSymbolsResponse{}
+		req := &pb.TradingSymbolsRequest{UserId: c.config.UserId}
+		for {
+			page, err := c.client.GetTradingSymbols(ctx, req)
+			if err != nil {
+				return err
+			}
+			resp.Symbols = append(resp.Symbols, page.Symbols...)
+			resp.Halted = page.Halted
+			if req.PageToken = page.NextPageToken; req.PageToken == "" {
+				break
+			}
+		}
+
+		t := &table{headers: []string{"symbol", "status", "paper", "reason"}}
+		for _, symbol := range resp.Symbols {
+			t.rows = append(t.rows, []string{symbol.Symbol, symbol.Status.String(), strconv.FormatBool(symbol.Paper), symbol.SuspendReason})
+		}
+		return printResponse(c.out, c.config.Output, resp, t)
+	}
+
+	flags := flag.NewFlagSet("symbols "+args[0], flag.ContinueOnError)
+	paper := flags.Bool("paper", false, "trade the symbol on paper, only for prepare")
+	if err := flags.Parse(args[1:]); err != nil || flags.NArg() != 1 {
+		return errUsage
+	}
+
+	req := &pb.SymbolRequest{UserId: c.config.UserId, Symbol: flags.Arg(0), Paper: *paper}
+	var err error
+	switch args[0] {
+	case "prepare":
+		_, err = c.client.SymbolTradingPrepare(ctx, req)
+	case "start":
+		_, err = c.client.SymbolTradingStart(ctx, req)
+	case "stop":
+		if err := c.confirm(fmt.Sprintf("Stop trading %s and remove it?", req.Symbol)); err != nil {
+			return err
+		}
+		_, err = c.client.SymbolTradingStop(ctx, req)
+	case "suspend":
+		_, err = c.client.SymbolTradingSuspend(ctx, req)
+	case "resume":
+		_, err = c.client.SymbolTradingResume(ctx, req)
+	default:
+		return errUsage
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.done(&pb.EmptyResponse{}, fmt.Sprintf("%s: %s done", req.Symbol, args[0]))
+}
+
+func (c *ctl) balances(ctx context.Context, args []string) error {
+	if len(args) > 0 && args[0] == "history" {
+		return c.balanceHistory(ctx, args[1:])
+	}
+
+	flags := flag.NewFlagSet("balances", flag.ContinueOnError)
+	quote := flags.String("quote", "", "quote currency of the values, usdt by default")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		return errUsage
+	}
+
+	resp, err := c.client.GetSymbolBalances(ctx, &pb.SymbolBalancesRequest{
+		UserId:        c.config.UserId,
+		QuoteCurrency: *quote,
+	})
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"symbol", "amount", resp.QuoteCurrency, "paper"}}
+	for _, balance := range resp.Balances {
+		t.rows = append(t.rows, []string{balance.Symbol, balance.AmountDecimal, balance.QuoteValueDecimal, "false"})
+	}
+	for _, balance := range resp.PaperBalances {
+		t.rows = append(t.rows, []string{balance.Symbol, balance.AmountDecimal, balance.QuoteValueDecimal, "true"})
+	}
+	t.rows = append(t.rows, []string{"total", "", resp.TotalDecimal, "false"})
+	if len(resp.PaperBalances) > 0 {
+		t.rows = append(t.rows, []string{"total", "", resp.PaperTotalDecimal, "true"})
+	}
+
+	return printResponse(c.out, c.config.Output, resp, t)
+}
+
+func (c *ctl) balanceHistory(ctx context.Context, args []string) error {
+	var symbols stringsFlag
+	flags := flag.NewFlagSet("balances history", flag.ContinueOnError)
+	flags.Var(&symbols, "symbol", "show the history of the symbol, can be repeated")
+	from := flags.String("from", "", "show the history since the time, RFC 3339, 7 days ago by default")
+	to := flags.String("to", "", "show the history before the time, RFC 3339")
+	interval := flags.String("interval", "hour", "hour or day")
+	timezone := flags.String("tz", "", "timezone days start in like Europe/Berlin, UTC if empty")
+	quote := flags.String("quote", "", "quote currency of the values, usdt by default")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		return errUsage
+	}
+
+	historyInterval, ok := pb.BalanceHistoryRequest_Interval_value[strings.ToUpper(*interval)]
+	if !ok {
+		return errUsage
+	}
+	req := &pb.BalanceHistoryRequest{
+		UserId:        c.config.UserId,
+		Symbols:       symbols,
+		Interval:      pb.BalanceHistoryRequest_Interval(historyInterval),
+		Timezone:      *timezone,
+		QuoteCurrency: *quote,
+	}
+	if err := parseTimeRange(*from, *to, &req.DateFrom, &req.DateTo); err != nil {
+		return err
+	}
+
+	resp, err := c.client.GetBalanceHistory(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"time", "symbol", "amount", resp.QuoteCurrency, "min", "max", "paper"}}
+	for _, history := range resp.Symbols {
+		for _, point := range history.Points {
+			t.rows = append(t.rows, []string{
+				formatTime(point.Time.AsTime()),
+				history.Symbol,
+				point.BalanceDecimal,
+				point.QuoteValueDecimal,
+				point.MinQuoteValueDecimal,
+				point.MaxQuoteValueDecimal,
+				strconv.FormatBool(history.Paper),
+			})
+		}
+	}
+	for _, point := range resp.Totals {
+		t.rows = append(t.rows, []string{formatTime(point.Time.AsTime()), "total", "", point.QuoteValueDecimal, "", "", "false"})
+	}
+
+	return printResponse(c.out, c.config.Output, resp, t)
+}
+
+func (c *ctl) limits(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "get":
+		resp, err := c.client.GetSymbolLimits(ctx, &pb.GetSymbolLimitsRequest{
+			UserId:  c.config.UserId,
+			Symbols: args[1:],
+		})
+		if err != nil {
+			return err
+		}
+
+		t := &table{headers: []string{"symbol", "limit", "max drawdown"}}
+		for _, limit := range resp.Limits {
+			t.rows = append(t.rows, []string{limit.Symbol, limit.LimitDecimal, limit.MaxDrawdownDecimal})
+		}
+		return printResponse(c.out, c.config.Output, resp, t)
+	case "set":
+		if len(args) < 2 {
+			return errUsage
+		}
+
+		req := &pb.SetSymbolLimitsRequest{UserId: c.config.UserId}
+		for _, arg := range args[1:] {
+			parts := strings.SplitN(arg, "=", 2)
+			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				return errors.New(fmt.Sprintf("invalid limit '%s', expected <symbol>=<limit>[:<max drawdown>]", arg))
+			}
+			values := strings.SplitN(parts[1], ":", 2)
+			limit := &pb.SymbolLimit{Symbol: parts[0], LimitDecimal: values[0]}
+			if len(values) == 2 {
+				limit.MaxDrawdownDecimal = values[1]
+			}
+			req.Limits = append(req.Limits, limit)
+		}
+
+		if _, err := c.client.SetSymbolLimits(ctx, req); err != nil {
+			return err
+		}
+		return c.done(&pb.EmptyResponse{}, fmt.Sprintf("%d limits set", len(req.Limits)))
+	default:
+		return errUsage
+	}
+}
+
+func (c *ctl) deals(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "list":
+		var symbols stringsFlag
+		flags := flag.NewFlagSet("deals list", flag.ContinueOnError)
+		flags.Var(&symbols, "symbol", "show deals of the symbol, can be repeated")
+		sortBy := flags.String("sort", "created_at", "sort by created_at, delta_percent or amount")
+		descending := flags.Bool("desc", false, "sort in descending order")
+		if err := flags.Parse(args[1:]); err != nil || flags.NArg() != 0 {
+			return errUsage
+		}
+		sortField, ok := pb.DealsRequest_SortField_value[strings.ToUpper(*sortBy)]
+		if !ok {
+			return errUsage
+		}
+
+		resp := &pb.DealsResponse{}
+		req := &pb.DealsRequest{
+			UserId:     c.config.UserId,
+			All:        len(symbols) == 0,
+			Symbols:    symbols,
+			SortBy:     pb.DealsRequest_SortField(sortField),
+			Descending: *descending,
+			PageSize:   1000,
+		}
+		for {
+			page, err := c.client.GetActiveDeals(ctx, req)
+			if err != nil {
+				return err
+			}
+			resp.Deals = append(resp.Deals, page.Deals...)
+			if req.PageToken = page.NextPageToken; req.PageToken == "" {
+				break
+			}
+		}
+
+		t := &table{headers: []string{"id", "symbol", "created", "amount", "amount currency", "delta", "delta %", "stop", "max", "paper"}}
+		for _, deal := range resp.Deals {
+			t.rows = append(t.rows, []string{
+				deal.DealId,
+				deal.Symbol,
+				formatTime(deal.CreatedAt.AsTime()),
+				deal.AmountDecimal,
+				deal.AmountCurrencyDecimal,
+				deal.DeltaAmountDecimal,
+				deal.DeltaPercentDecimal,
+				strconv.FormatFloat(float64(deal.Prediction.GetStop()), 'f', -1, 32),
+				strconv.FormatFloat(float64(deal.Prediction.GetMax()), 'f', -1, 32),
+				strconv.FormatBool(deal.Paper),
+			})
+		}
+		return printResponse(c.out, c.config.Output, resp, t)
+	case "close":
+		flags := flag.NewFlagSet("deals close", flag.ContinueOnError)
+		all := flags.Bool("all", false, "close all open deals")
+		if err := flags.Parse(args[1:]); err != nil || *all == (flags.NArg() > 0) {
+			return errUsage
+		}
+
+		question := fmt.Sprintf("Close deals %s?", strings.Join(flags.Args(), ", "))
+		if *all {
+			question = "Close ALL open deals?"
+		}
+		if err := c.confirm(question); err != nil {
+			return err
+		}
+
+		_, err := c.client.CloseDeals(ctx, &pb.DealsRequest{
+			UserId:  c.config.UserId,
+			All:     *all,
+			DealIds: flags.Args(),
+		})
+		if err != nil {
+			return err
+		}
+		return c.done(&pb.EmptyResponse{}, "deals closed")
+	default:
+		return errUsage
+	}
+}
+
+func (c *ctl) symbolConfig(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	var resp *pb.SymbolConfigResponse
+	var err error
+	switch args[0] {
+	case "get":
+		flags := flag.NewFlagSet("config get", flag.ContinueOnError)
+		history := flags.Bool("history", false, "show the previous versions as well")
+		if err := flags.Parse(args[1:]); err != nil || flags.NArg() != 1 {
+			return errUsage
+		}
+
+		resp, err = c.client.GetSymbolConfig(ctx, &pb.GetSymbolConfigRequest{
+			UserId:  c.config.UserId,
+			Symbol:  flags.Arg(0),
+			History: *history,
+		})
+	case "set":
+		flags := flag.NewFlagSet("config set", flag.ContinueOnError)
+		expectedVersion := flags.Int("expect-version", 0, "fail unless the config is at this version")
+		if err := flags.Parse(args[1:]); err != nil || flags.NArg() < 2 {
+			return errUsage
+		}
+
+		config := &pb.SymbolConfig{}
+		for _, arg := range flags.Args()[1:] {
+			parts := strings.SplitN(arg, "=", 2)
+			if len(parts) != 2 {
+				return errors.New(fmt.Sprintf("invalid value '%s', expected <name>=<value>", arg))
+			}
+			switch parts[0] {
+			case "timeframe":
+				config.Timeframe = parts[1]
+			case "entry-delta":
+				config.EntryDeltaPercent = parts[1]
+			case "stop":
+				config.StopPercent = parts[1]
+			case "max":
+				config.MaxPercent = parts[1]
+			case "max-deals":
+				n, err := strconv.ParseInt(parts[1], 10, 32)
+				if err != nil {
+					return errors.New(fmt.Sprintf("invalid max-deals '%s'", parts[1]))
+				}
+				config.MaxConcurrentDeals = int32(n)
+			case "order-size":
+				config.OrderSize = parts[1]
+			default:
+				return errors.New(fmt.Sprintf("unknown config value '%s'", parts[0]))
+			}
+		}
+
+		resp, err = c.client.SetSymbolConfig(ctx, &pb.SetSymbolConfigRequest{
+			UserId:          c.config.UserId,
+			Symbol:          flags.Arg(0),
+			Config:          config,
+			ExpectedVersion: int32(*expectedVersion),
+		})
+	default:
+		return errUsage
+	}
+	if err != nil {
+		return err
+	}
+
+	t := &table{headers: []string{"version", "timeframe", "entry delta", "stop", "max", "max deals", "order size", "updated by", "updated"}}
+	for _, config := range append([]*pb.SymbolConfig{resp.Config}, resp.History...) {
+		updated := ""
+		if config.UpdatedAt != nil {
+			updated = formatTime(config.UpdatedAt.AsTime())
+		}
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(int(config.Version)),
+			config.Timeframe,
+			config.EntryDeltaPercent,
+			config.StopPercent,
+			config.MaxPercent,
+			strconv.Itoa(int(config.MaxConcurrentDeals)),
+			config.OrderSize,
+			strconv.FormatInt(config.UpdatedBy, 10),
+			updated,
+		})
+	}
+	return printResponse(c.out, c.config.Output, resp, t)
+}
+
+func (c *ctl) predictions(ctx context.Context, args []string) error {
+	var symbols stringsFlag
+	flags := flag.NewFlagSet("predictions", flag.ContinueOnError)
+	flags.Var(&symbols, "symbol", "show stats of the symbol, can be repeated")
+	from := flags.String("from", "", "count deals closed since the time, RFC 3339")
+	to := flags.String("to", "", "count deals closed before the time, RFC 3339")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		return errUsage
+	}
+
+	req := &pb.PredictionStatsRequest{UserId: c.config.UserId, Symbols: symbols}
+	if err := parseTimeRange(*from, *to, &req.DateFrom, &req.DateTo); err != nil {
+		return err
+	}
+
+	resp, err := c.client.GetPredictionStats(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	formatFloat := func(f float64) string { return strconv.FormatFloat(f, 'f', 2, 64) }
+	t := &table{headers: []string{
+		"symbol", "timeframe", "deals", "stop first", "max first", "none",
+		"stop hit", "max hit", "avg stop", "avg max", "avg mae %", "avg mfe %", "avg result %",
+	}}
+	for _, stats := range resp.Stats {
+		t.rows = append(t.rows, []string{
+			stats.Symbol,
+			stats.Timeframe,
+			strconv.Itoa(int(stats.Deals)),
+			strconv.Itoa(int(stats.StopHitFirst)),
+			strconv.Itoa(int(stats.MaxHitFirst)),
+			strconv.Itoa(int(stats.NoneHit)),
+			formatFloat(stats.StopHitRatio),
+			formatFloat(stats.MaxHitRatio),
+			formatFloat(stats.AvgPredictedStop),
+			formatFloat(stats.AvgPredictedMax),
+			formatFloat(stats.AvgMaxAdversePercent),
+			formatFloat(stats.AvgMaxFavorablePercent),
+			formatFloat(stats.AvgResultPercent),
+		})
+	}
+	return printResponse(c.out, c.config.Output, resp, t)
+}
+
+func (c *ctl) schedules(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "list":
+		resp, err := c.client.ListSchedules(ctx, &pb.EmptyRequest{UserId: c.config.UserId})
+		if err != nil {
+			return err
+		}
+
+		t := &table{headers: []string{"id", "symbol", "status", "cron", "next run", "last run", "last error"}}
+		for _, schedule := range resp.Schedules {
+			nextRun, lastRun := "", ""
+			if schedule.NextRunAt != nil {
+				nextRun = formatTime(schedule.NextRunAt.AsTime())
+			}
+			if schedule.LastRunAt != nil {
+				lastRun = formatTime(schedule.LastRunAt.AsTime())
+			}
+			t.rows = append(t.rows, []string{
+				schedule.ScheduleId,
+				schedule.Symbol,
+				schedule.Status.String(),
+				schedule.Cron,
+				nextRun,
+				lastRun,
+				schedule.LastError,
+			})
+		}
+		return printResponse(c.out, c.config.Output, resp, t)
+	case "add":
+		flags := flag.NewFlagSet("schedules add", flag.ContinueOnError)
+		at := flags.String("at", "", "time of a single change, RFC 3339")
+		cron := flags.String("cron", "", "cron expression of a recurring change, in UTC")
+		if err := flags.Parse(args[1:]); err != nil || flags.NArg() != 2 || (*at == "") == (*cron == "") {
+			return errUsage
+		}
+
+		req := &pb.ScheduleStatusChangeRequest{
+			UserId: c.config.UserId,
+			Symbol: flags.Arg(0),
+			Cron:   *cron,
+		}
+		switch flags.Arg(1) {
+		case "suspend":
+			req.Status = pb.TradingSymbol_SUSPENDED
+		case "resume":
+			req.Status = pb.TradingSymbol_ACTIVE
+		default:
+			return errUsage
+		}
+		if *at != "" {
+			runAt, err := time.Parse(time.RFC3339, *at)
+			if err != nil {
+				return errors.New(fmt.Sprintf("invalid time '%s', expected RFC 3339", *at))
+			}
+			req.RunAt = timestamppb.New(runAt)
+		}
+
+		resp, err := c.client.ScheduleStatusChange(ctx, req)
+		if err != nil {
+			return err
+		}
+		if c.config.Output == "json" {
+			return printResponse(c.out, c.config.Output, resp, nil)
+		}
+		fmt.Fprintf(c.out, "schedule %s added, next run at %s\n", resp.Schedule.ScheduleId, formatTime(resp.Schedule.NextRunAt.AsTime()))
+		return nil
+	case "cancel":
+		if len(args) != 2 {
+			return errUsage
+		}
+		if _, err := c.client.CancelSchedule(ctx, &pb.CancelScheduleRequest{UserId: c.config.UserId, ScheduleId: args[1]}); err != nil {
+			return err
+		}
+		return c.done(&pb.EmptyResponse{}, fmt.Sprintf("schedule %s cancelled", args[1]))
+	default:
+		return errUsage
+	}
+}
+
+func (c *ctl) approvals(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "list":
+		resp, err := c.client.ListPendingApprovals(ctx, &pb.EmptyRequest{UserId: c.config.UserId})
+		if err != nil {
+			return err
+		}
+
+		t := &table{headers: []string{"id", "method", "request", "requested by", "expires at"}}
+		for _, approval := range resp.Approvals {
+			t.rows = append(t.rows, []string{
+				approval.ApprovalId,
+				approval.Method,
+				approval.Request,
+				strconv.FormatInt(approval.RequestedBy, 10),
+				formatTime(approval.ExpiresAt.AsTime()),
+			})
+		}
+		return printResponse(c.out, c.config.Output, resp, t)
+	case "approve":
+		if len(args) != 2 {
+			return errUsage
+		}
+		if err := c.confirm(fmt.Sprintf("Approve and run %s?", args[1])); err != nil {
+			return err
+		}
+
+		resp, err := c.client.Approve(ctx, &pb.ApprovalRequest{UserId: c.config.UserId, ApprovalId: args[1]})
+		if err != nil {
+			return err
+		}
+		if c.config.Output == "json" {
+			return printResponse(c.out, c.config.Output, resp, nil)
+		}
+		approval := resp.Approval
+		fmt.Fprintf(c.out, "approval %s of %s %s\n", approval.ApprovalId, approval.Method, approval.Status)
+		if n := len(approval.Events); n > 0 && approval.Events[n-1].Message != "" {
+			fmt.Fprintln(c.out, approval.Events[n-1].Message)
+		}
+		return nil
+	case "reject":
+		flags := flag.NewFlagSet("approvals reject", flag.ContinueOnError)
+		reason := flags.String("reason", "", "reason of the rejection")
+		if err := flags.Parse(args[1:]); err != nil || flags.NArg() != 1 {
+			return errUsage
+		}
+
+		_, err := c.client.Reject(ctx, &pb.ApprovalRequest{
+			UserId:     c.config.UserId,
+			ApprovalId: flags.Arg(0),
+			Reason:     *reason,
+		})
+		if err != nil {
+			return err
+		}
+		return c.done(&pb.EmptyResponse{}, fmt.Sprintf("approval %s rejected", flags.Arg(0)))
+	default:
+		return errUsage
+	}
+}
+
+func (c *ctl) halt(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("halt", flag.ContinueOnError)
+	closeDeals := flags.Bool("close-deals", false, "close all open deals as well")
+	reason := flags.String("reason", "", "reason of the halt")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		return errUsage
+	}
+
+	question := "HALT all trading?"
+	if *closeDeals {
+		question = "HALT all trading and close ALL open deals?"
+	}
+	if err := c.confirm(question); err != nil {
+		return err
+	}
+
+	_, err := c.client.EmergencyHalt(ctx, &pb.EmergencyHaltRequest{
+		UserId:     c.config.UserId,
+		CloseDeals: *closeDeals,
+		Reason:     *reason,
+	})
+	if err != nil {
+		return err
+	}
+	return c.done(&pb.EmptyResponse{}, "trading halted")
+}
+
+// confirm asks the operator to type "yes" unless -yes was given.
+func (c *ctl) confirm(question string) error {
+	if c.yes {
+		return nil
+	}
+
+	fmt.Fprintf(c.out, "%s Type 'yes' to continue: ", question)
+	answer, err := c.in.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if strings.TrimSpace(answer) != "yes" {
+		return errAborted
+	}
+	return nil
+}
+
+// done reports a successful command without a result.
+func (c *ctl) done(resp *pb.EmptyResponse, message string) error {
+	if c.config.Output == "json" {
+		return printResponse(c.out, c.config.Output, resp, nil)
+	}
+	_, err := fmt.Fprintln(c.out, message)
+	return err
+}
+
+// parseTimeRange sets the bounds of a range from RFC 3339 flags, empty flags
+// leave their bound unset.
+func parseTimeRange(from, to string, fromTarget, toTarget **timestamppb.Timestamp) error {
+	for _, bound := range []struct {
+		value  string
+		target **timestamppb.Timestamp
+	}{{from, fromTarget}, {to, toTarget}} {
+		if bound.value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, bound.value)
+		if err != nil {
+			return errors.New(fmt.Sprintf("invalid time '%s', expected RFC 3339", bound.value))
+		}
+		*bound.target = timestamppb.New(t)
+	}
+	return nil
+}
+
+type stringsFlag []string
+
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func (c *ctl) export(ctx context.Context, args []string) error {
+	var symbols stringsFlag
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.Var(&symbols, "symbol", "export deals of the symbol, can be repeated")
+	from := flags.String("from", "", "export deals since the time, RFC 3339")
+	to := flags.String("to", "", "export deals before the time, RFC 3339")
+	format := flags.String("format", "csv", "csv or xlsx")
+	columns := flags.String("columns", "", "comma separated columns, all if empty")
+	timezone := flags.String("tz", "", "timezone of the times like Europe/Berlin, UTC if empty")
+	out := flags.String("out", "", "output file, - for stdout, the name sent by the server if empty")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		return errUsage
+	}
+
+	exportFormat, ok := pb.ExportDealsRequest_Format_value[strings.ToUpper(*format)]
+	if !ok {
+		return errUsage
+	}
+	req := &pb.ExportDealsRequest{
+		UserId:   c.config.UserId,
+		Symbols:  symbols,
+		Format:   pb.ExportDealsRequest_Format(exportFormat),
+		Timezone: *timezone,
+	}
+	if *columns != "" {
+		req.Columns = strings.Split(*columns, ",")
+	}
+	if err := parseTimeRange(*from, *to, &req.DateFrom, &req.DateTo); err != nil {
+		return err
+	}
+
+	stream, err := c.client.ExportDeals(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer
+	var file *os.File
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			if file != nil {
+				file.Close()
+				os.Remove(file.Name())
+			}
+			return err
+		}
+
+		if w == nil {
+			name := *out
+			if name == "" {
+				name = chunk.FileName
+			}
+			if name == "-" {
+				w = c.out
+			} else {
+				if file, err = os.Create(name); err != nil {
+					return err
+				}
+				w = file
+			}
+		}
+		if _, err := w.Write(chunk.Data); err != nil {
+			return err
+		}
+	}
+
+	if file == nil {
+		return nil
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.out, "exported to %s\n", file.Name())
+	return err
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	utils "github.com/mikevel2955/hermes-utils"
+)
+
+// ctlConfig is read from the config file first, environment variables
+// override its values.
+type ctlConfig struct {
+	Addr    string        `json:"addr"`
+	UserId  int64         `json:"user_id"`
+	Timeout time.Duration `json:"-"`
+	Output  string        `json:"output"`
+}
+
+type ctlEnv struct {
+	Addr    string        `env:"GANDALF_ADDR"`
+	UserId  string        `env:"GANDALF_USER_ID"`
+	Timeout time.Duration `env:"GANDALF_TIMEOUT" def:"10s"`
+	Output  string        `env:"GANDALF_OUTPUT"`
+}
+
+const defaultConfigName = ".gandalfctl.json"
+
+func readConfig(path string) (*ctlConfig, error) {
+	config := &ctlConfig{
+		Addr:   "127.0.0.1:40001",
+		Output: "table",
+	}
+
+	explicit := path != ""
+	if !explicit {
+		home, err := os.UserHomeDir()
+		if err == nil {
+			path = filepath.Join(home, defaultConfigName)
+		}
+	}
+	if path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil && (explicit || !os.IsNotExist(err)) {
+			return nil, err
+		}
+		if err == nil {
+			if err := json.Unmarshal(data, config); err != nil {
+				return nil, errors.New(fmt.Sprintf("can't parse config %s: %v", path, err))
+			}
+		}
+	}
+
+	env := ctlEnv{}
+	if err := utils.ReadConfig(&env); err != nil {
+		return nil, err
+	}
+	if env.Addr != "" {
+		config.Addr = env.Addr
+	}
+	if env.UserId != "" {
+		if _, err := fmt.Sscan(env.UserId, &config.UserId); err != nil {
+			return nil, errors.New(fmt.Sprintf("can't parse GANDALF_USER_ID: %v", err))
+		}
+	}
+	if env.Output != "" {
+		config.Output = env.Output
+	}
+	config.Timeout = env.Timeout
+
+	return config, nil
+}
@@ -0,0 +1,105 @@
+// Command gandalfctl is the command-line client of the Gandalf service.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"google.golang.org/grpc"
+)
+
+const usage = `usage: gandalfctl [-config file] [-o table|json|csv] [-yes] <command>
+
+commands:
+  symbols list
+  symbols prepare [-paper] <symbol>
+  symbols start|stop|suspend|resume <symbol>
+  balances [-quote currency]
+  balances history [-symbol symbol]... [-from time] [-to time] [-interval hour|day]
+                   [-tz zone] [-quote currency]
+  limits get [symbol...]
+  limits set <symbol>=<limit>[:<max drawdown>]...
+  deals list [-symbol symbol]... [-sort created_at|delta_percent|amount] [-desc]
+  deals close -all | <deal id>...
+  config get [-history] <symbol>
+  config set [-expect-version n] <symbol> <name>=<value>...
+      names: timeframe, entry-delta, stop, max, max-deals, order-size
+  predictions [-symbol symbol]... [-from time] [-to time]
+  export [-symbol symbol]... [-from time] [-to time] [-format csv|xlsx]
+         [-columns name,...] [-tz zone] [-out file]
+      columns: record, id, symbol, status, time, closed_at, amount, amount_currency,
+      delta_amount, delta_percent, open_price, stop, max, timeframe, paper, balance,
+      quote_value, quote
+  schedules list
+  schedules add (-at time | -cron expr) <symbol> suspend|resume
+  schedules cancel <schedule id>
+  approvals list
+  approvals approve <approval id>
+  approvals reject [-reason text] <approval id>
+  halt [-close-deals] [-reason text]
+  unhalt
+
+The config file defaults to ~/.gandalfctl.json, environment variables
+GANDALF_ADDR, GANDALF_USER_ID, GANDALF_OUTPUT and GANDALF_TIMEOUT override it.
+`
+
+func main() {
+	flags := flag.NewFlagSet("gandalfctl", flag.ExitOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	configPath := flags.String("config", "", "config file")
+	output := flags.String("o", "", "output format: table, json or csv")
+	yes := flags.Bool("yes", false, "don't ask for confirmation of destructive commands")
+	_ = flags.Parse(os.Args[1:])
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	config, err := readConfig(*configPath)
+	if err != nil {
+		fail(err)
+	}
+	if *output != "" {
+		config.Output = *output
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, config.Addr, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		fail(errors.New(fmt.Sprintf("cannot connect to %s: %v", config.Addr, err)))
+	}
+	defer conn.Close()
+
+	c := &ctl{
+		client: pb.NewGandalfClient(conn),
+		config: config,
+		out:    os.Stdout,
+		in:     bufio.NewReader(os.Stdin),
+		yes:    *yes,
+	}
+	if err := c.run(ctx, flags.Args()); err != nil {
+		if err == errUsage {
+			flags.Usage()
+			os.Exit(2)
+		}
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "gandalfctl: %v\n", err)
+	os.Exit(1)
+}
+
+func formatTime(t time.Time) string {
+	return t.Local().Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+const migrateUsage = "usage: gandalf migrate up|down|status"
+
+func runMigrateCommand(migrator *Migrator, args []string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	ctx := context.Background()
+	switch args[0] {
+	case "up":
+		return migrator.Up(ctx)
+	case "down":
+		return migrator.Down(ctx)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return w.Flush()
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+const seedUsage = "usage: gandalf seed [-force -confirm-db <db name>] <fixtures file>"
+
+// runSeedCommand loads fixtures into empty collections. With -force the
+// collections are dropped first, which requires -confirm-db to repeat the name
+// of the configured database.
+func runSeedCommand(storage *Storage, dbName string, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	force := flags.Bool("force", false, "drop symbols and deals before seeding")
+	confirmDb := flags.String("confirm-db", "", "name of the database to drop, required with -force")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New(seedUsage)
+	}
+
+	fixtures, err := ReadFixtures(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if *force {
+		if *confirmDb != dbName {
+			return errors.New(fmt.Sprintf("refusing to drop '%s': -confirm-db doesn't match the database name", dbName))
+		}
+		if err := storage.Drop(ctx); err != nil {
+			return err
+		}
+	}
+
+	return storage.LoadFixtures(ctx, fixtures)
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSchedule is a parsed standard five-field cron expression: minute, hour,
+// day of month, month and day of week. Fields accept *, numbers, ranges, lists
+// and steps, e.g. "*/15 2-4 * * 1,3". Sunday is 0 or 7.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	// as in cron, when both days are restricted either of them matches
+	domAny, dowAny bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = []cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// cronSearchLimit bounds the search of the next run, parseCron already
+// rejects expressions like "0 0 31 2 *" that never match.
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// cronMonthDays are the most days a month has, February in leap years.
+var cronMonthDays = [13]int{0, 31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+func parseCron(expr string) (*cronSchedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(cronFields) {
+		return nil, errors.New(fmt.Sprintf("cron expression '%s' must have %d fields", expr, len(cronFields)))
+	}
+
+	bits := make([]uint64, len(parts))
+	for i, part := range parts {
+		var err error
+		if bits[i], err = parseCronField(part, cronFields[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	// Sunday may be given as 7
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+
+	schedule := &cronSchedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		domAny: parts[2] == "*",
+		dowAny: parts[4] == "*",
+	}
+	if !schedule.hasDays() {
+		return nil, errors.New(fmt.Sprintf("cron expression '%s' never matches", expr))
+	}
+	return schedule, nil
+}
+
+// hasDays tells whether any day matches the schedule. Only days of month
+// restricted to days the months don't have match none, a restricted day of
+// week matches every week.
+func (c *cronSchedule) hasDays() bool {
+	if c.domAny || !c.dowAny {
+		return true
+	}
+	for month := 1; month <= 12; month++ {
+		if c.month&(1<<uint(month)) == 0 {
+			continue
+		}
+		for day := 1; day <= cronMonthDays[month]; day++ {
+			if c.dom&(1<<uint(day)) != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parseCronField(s string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(s, ",") {
+		step := 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n <= 0 {
+				return 0, errors.New(fmt.Sprintf("invalid step in %s '%s'", field.name, s))
+			}
+			step, item = n, item[:i]
+		}
+
+		from, to := field.min, field.max
+		if item != "*" {
+			bounds := strings.SplitN(item, "-", 2)
+			var err error
+			if from, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, errors.New(fmt.Sprintf("invalid %s '%s'", field.name, s))
+			}
+			to = from
+			if len(bounds) == 2 {
+				if to, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, errors.New(fmt.Sprintf("invalid %s '%s'", field.name, s))
+				}
+			} else if step > 1 {
+				to = field.max
+			}
+		}
+		if from < field.min || to > field.max || from > to {
+			return 0, errors.New(fmt.Sprintf("%s '%s' is out of range %d-%d", field.name, s, field.min, field.max))
+		}
+
+		for n := from; n <= to; n += step {
+			bits |= 1 << uint(n)
+		}
+	}
+	return bits, nil
+}
+
+// Next returns the first time after t matching the schedule, or the zero time
+// if nothing matches within cronSearchLimit.
+func (c *cronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
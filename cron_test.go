@@ -0,0 +1,120 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseCronField(t *testing.T) {
+	tests := []struct {
+		field string
+		want  []int
+	}{
+		{"*", []int{0, 1, 2, 3, 4, 5, 6, 7}},
+		{"3", []int{3}},
+		{"1,5", []int{1, 5}},
+		{"2-4", []int{2, 3, 4}},
+		{"*/3", []int{0, 3, 6}},
+		{"1-6/2", []int{1, 3, 5}},
+		{"2/4", []int{2, 6}},
+		{"0,4-5,*/7", []int{0, 4, 5, 7}},
+	}
+
+	for _, test := range tests {
+		bits, err := parseCronField(test.field, cronField{"test", 0, 7})
+		if err != nil {
+			t.Errorf("parseCronField(%q) failed: %v", test.field, err)
+			continue
+		}
+
+		got := []int{}
+		for n := 0; n <= 7; n++ {
+			if bits&(1<<uint(n)) != 0 {
+				got = append(got, n)
+			}
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parseCronField(%q) = %v, want %v", test.field, got, test.want)
+		}
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 0 *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"1-b * * * *",
+		// days the months never have
+		"0 0 30 2 *",
+		"0 0 31 2 *",
+		"0 0 31 4,6,9,11 *",
+	}
+
+	for _, expr := range tests {
+		if _, err := parseCron(expr); err == nil {
+			t.Errorf("parseCron(%q) succeeded, want an error", expr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	date := func(year int, month time.Month, day, hour, min, sec int) time.Time {
+		return time.Date(year, month, day, hour, min, sec, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"every minute", "* * * * *", date(2024, 1, 1, 10, 7, 30), date(2024, 1, 1, 10, 8, 0)},
+		{"strictly after", "30 10 * * *", date(2024, 1, 1, 10, 30, 0), date(2024, 1, 2, 10, 30, 0)},
+		{"same minute later", "30 10 * * *", date(2024, 1, 1, 10, 29, 59), date(2024, 1, 1, 10, 30, 0)},
+		{"step", "*/15 * * * *", date(2024, 1, 1, 10, 7, 0), date(2024, 1, 1, 10, 15, 0)},
+		{"step into next hour", "*/15 * * * *", date(2024, 1, 1, 10, 45, 0), date(2024, 1, 1, 11, 0, 0)},
+		{"step from start", "5/20 * * * *", date(2024, 1, 1, 10, 26, 0), date(2024, 1, 1, 10, 45, 0)},
+		{"range", "0 9-17 * * *", date(2024, 1, 1, 18, 0, 0), date(2024, 1, 2, 9, 0, 0)},
+		{"range step", "0 2-10/4 * * *", date(2024, 1, 1, 3, 0, 0), date(2024, 1, 1, 6, 0, 0)},
+		{"range step end", "0 2-10/4 * * *", date(2024, 1, 1, 10, 0, 0), date(2024, 1, 2, 2, 0, 0)},
+		{"list", "1,30 * * * *", date(2024, 1, 1, 10, 1, 0), date(2024, 1, 1, 10, 30, 0)},
+		{"day of month only", "0 0 13 * *", date(2024, 1, 1, 0, 0, 0), date(2024, 1, 13, 0, 0, 0)},
+		{"day of week only", "0 0 * * 1", date(2024, 1, 1, 0, 0, 0), date(2024, 1, 8, 0, 0, 0)},
+		// 2024-01-01 is a Monday, the 5th and 12th are Fridays
+		{"either day, week first", "0 0 13 * 5", date(2024, 1, 1, 0, 0, 0), date(2024, 1, 5, 0, 0, 0)},
+		{"either day, week again", "0 0 13 * 5", date(2024, 1, 6, 0, 0, 0), date(2024, 1, 12, 0, 0, 0)},
+		{"either day, month", "0 0 13 * 5", date(2024, 1, 12, 0, 0, 0), date(2024, 1, 13, 0, 0, 0)},
+		{"day missing in month matches by week", "0 0 31 2 1", date(2024, 2, 1, 0, 0, 0), date(2024, 2, 5, 0, 0, 0)},
+		{"sunday as 0", "0 12 * * 0", date(2024, 1, 1, 0, 0, 0), date(2024, 1, 7, 12, 0, 0)},
+		{"sunday as 7", "0 12 * * 7", date(2024, 1, 1, 0, 0, 0), date(2024, 1, 7, 12, 0, 0)},
+		{"range to sunday as 7", "0 12 * * 5-7", date(2024, 1, 6, 13, 0, 0), date(2024, 1, 7, 12, 0, 0)},
+		{"skips short month", "0 0 31 * *", date(2024, 4, 1, 0, 0, 0), date(2024, 5, 31, 0, 0, 0)},
+		{"leap day", "0 0 29 2 *", date(2024, 3, 1, 0, 0, 0), date(2028, 2, 29, 0, 0, 0)},
+		{"month", "0 0 1 3,9 *", date(2024, 3, 2, 0, 0, 0), date(2024, 9, 1, 0, 0, 0)},
+		{"new year", "0 0 1 1 *", date(2024, 6, 1, 0, 0, 0), date(2025, 1, 1, 0, 0, 0)},
+	}
+
+	for _, test := range tests {
+		cron, err := parseCron(test.expr)
+		if err != nil {
+			t.Errorf("%s: parseCron(%q) failed: %v", test.name, test.expr, err)
+			continue
+		}
+		if got := cron.Next(test.from); !got.Equal(test.want) {
+			t.Errorf("%s: Next of %q from %s = %s, want %s", test.name, test.expr, test.from, got, test.want)
+		}
+	}
+}
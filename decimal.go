@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var decimalType = reflect.TypeOf(decimal.Decimal{})
+
+// newBsonRegistry returns the default registry extended with a codec that
+// stores decimal.Decimal as Decimal128. Doubles, integers and strings are
+// accepted on decode, so documents written before the switch to decimals
+// remain readable.
+func newBsonRegistry() *bsoncodec.Registry {
+	return bson.NewRegistryBuilder().
+		RegisterTypeEncoder(decimalType, bsoncodec.ValueEncoderFunc(encodeDecimal)).
+		RegisterTypeDecoder(decimalType, bsoncodec.ValueDecoderFunc(decodeDecimal)).
+		Build()
+}
+
+func encodeDecimal(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != decimalType {
+		return bsoncodec.ValueEncoderError{Name: "DecimalEncodeValue", Types: []reflect.Type{decimalType}, Received: val}
+	}
+
+	d128, err := primitive.ParseDecimal128(val.Interface().(decimal.Decimal).String())
+	if err != nil {
+		return err
+	}
+
+	return vw.WriteDecimal128(d128)
+}
+
+func decodeDecimal(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != decimalType {
+		return bsoncodec.ValueDecoderError{Name: "DecimalDecodeValue", Types: []reflect.Type{decimalType}, Received: val}
+	}
+
+	var d decimal.Decimal
+	switch vr.Type() {
+	case bsontype.Decimal128:
+		d128, err := vr.ReadDecimal128()
+		if err != nil {
+			return err
+		}
+		if d, err = decimal.NewFromString(d128.String()); err != nil {
+			return err
+		}
+	case bsontype.Double:
+		f, err := vr.ReadDouble()
+		if err != nil {
+			return err
+		}
+		d = decimal.NewFromFloat(f)
+	case bsontype.Int32:
+		i, err := vr.ReadInt32()
+		if err != nil {
+			return err
+		}
+		d = decimal.NewFromInt32(i)
+	case bsontype.Int64:
+		i, err := vr.ReadInt64()
+		if err != nil {
+			return err
+		}
+		d = decimal.NewFromInt(i)
+	case bsontype.String:
+		s, err := vr.ReadString()
+		if err != nil {
+			return err
+		}
+		if d, err = decimal.NewFromString(s); err != nil {
+			return err
+		}
+	case bsontype.Null:
+		if err := vr.ReadNull(); err != nil {
+			return err
+		}
+	default:
+		return errors.New(fmt.Sprintf("cannot decode %v into a decimal", vr.Type()))
+	}
+
+	val.Set(reflect.ValueOf(d))
+	return nil
+}
+
+// decimalFromPb reads a string-encoded decimal field of a request and falls
+// back to its deprecated float counterpart when the string is not set.
+func decimalFromPb(s string, f float32) (decimal.Decimal, error) {
+	if s == "" {
+		return decimal.NewFromFloat32(f), nil
+	}
+
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Zero, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid decimal '%s'", s))
+	}
+
+	return d, nil
+}
+
+// decimalToFloat fills the deprecated float fields of responses.
+func decimalToFloat(d decimal.Decimal) float32 {
+	f, _ := d.Float64()
+	return float32(f)
+}
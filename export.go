@@ -0,0 +1,359 @@
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportChunkSize is the size of the chunks an export is streamed in.
+const exportChunkSize = 64 << 10
+
+const (
+	exportRecordDeal    = "deal"
+	exportRecordBalance = "balance"
+)
+
+// exportRow is a deal or a balance of a symbol with every value formatted,
+// values that don't apply to the record are empty.
+type exportRow struct {
+	record         string
+	id             string
+	symbol         string
+	status         string
+	time           string
+	closedAt       string
+	amount         string
+	amountCurrency string
+	deltaAmount    string
+	deltaPercent   string
+	openPrice      string
+	stop           string
+	max            string
+	timeframe      string
+	paper          string
+	balance        string
+	quoteValue     string
+	quote          string
+}
+
+type exportColumn struct {
+	name    string
+	numeric bool
+	value   func(row *exportRow) string
+}
+
+// exportColumns are the columns of an export in their default order.
+var exportColumns = []exportColumn{
+	{"record", false, func(row *exportRow) string { return row.record }},
+	{"id", false, func(row *exportRow) string { return row.id }},
+	{"symbol", false, func(row *exportRow) string { return row.symbol }},
+	{"status", false, func(row *exportRow) string { return row.status }},
+	{"time", false, func(row *exportRow) string { return row.time }},
+	{"closed_at", false, func(row *exportRow) string { return row.closedAt }},
+	{"amount", true, func(row *exportRow) string { return row.amount }},
+	{"amount_currency", true, func(row *exportRow) string { return row.amountCurrency }},
+	{"delta_amount", true, func(row *exportRow) string { return row.deltaAmount }},
+	{"delta_percent", true, func(row *exportRow) string { return row.deltaPercent }},
+	{"open_price", true, func(row *exportRow) string { return row.openPrice }},
+	{"stop", true, func(row *exportRow) string { return row.stop }},
+	{"max", true, func(row *exportRow) string { return row.max }},
+	{"timeframe", false, func(row *exportRow) string { return row.timeframe }},
+	{"paper", false, func(row *exportRow) string { return row.paper }},
+	{"balance", true, func(row *exportRow) string { return row.balance }},
+	{"quote_value", true, func(row *exportRow) string { return row.quoteValue }},
+	{"quote", false, func(row *exportRow) string { return row.quote }},
+}
+
+// exportColumnsByName returns the columns in the requested order, all of them
+// if none are requested.
+func exportColumnsByName(names []string) ([]exportColumn, error) {
+	if len(names) == 0 {
+		return exportColumns, nil
+	}
+
+	columns := make([]exportColumn, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, column := range exportColumns {
+			if column.name == strings.ToLower(name) {
+				columns, found = append(columns, column), true
+				break
+			}
+		}
+		if !found {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unknown export column %s", name))
+		}
+	}
+	return columns, nil
+}
+
+func exportTime(t time.Time, location *time.Location) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(location).Format(time.RFC3339)
+}
+
+func dealExportRow(deal *Deal, closedAt time.Time, location *time.Location) *exportRow {
+	row := &exportRow{
+		record:         exportRecordDeal,
+		id:             deal.Id,
+		symbol:         deal.Symbol,
+		status:         "open",
+		time:           exportTime(deal.CreatedAt, location),
+		closedAt:       exportTime(closedAt, location),
+		amount:         deal.Amount.String(),
+		amountCurrency: deal.AmountCurrency.String(),
+		deltaAmount:    deal.DeltaAmount.String(),
+		deltaPercent:   deal.DeltaPercent.String(),
+		openPrice:      deal.OpenPrice.String(),
+		stop:           strconv.FormatFloat(float64(deal.Prediction.Stop), 'f', -1, 32),
+		max:            strconv.FormatFloat(float64(deal.Prediction.Max), 'f', -1, 32),
+		timeframe:      deal.Timeframe,
+		paper:          strconv.FormatBool(deal.Paper),
+	}
+	if !closedAt.IsZero() {
+		row.status = "closed"
+	}
+	return row
+}
+
+// balanceExportRow is the last snapshot of the bucket.
+func balanceExportRow(bucket *BalanceBucket, location *time.Location) *exportRow {
+	return &exportRow{
+		record:     exportRecordBalance,
+		symbol:     bucket.Symbol,
+		time:       exportTime(bucket.LastAt, location),
+		paper:      strconv.FormatBool(bucket.Paper),
+		balance:    bucket.Balance.String(),
+		quoteValue: bucket.Value.String(),
+		quote:      bucket.Quote,
+	}
+}
+
+// exportWriter writes the rows of an export as cells of the columns.
+type exportWriter struct {
+	cells   cellWriter
+	columns []exportColumn
+	numeric []bool
+}
+
+// cellWriter writes rows of cells in one of the file formats, numeric cells
+// are written as numbers by the formats that have types.
+type cellWriter interface {
+	WriteCells(cells []string, numeric []bool) error
+	Close() error
+}
+
+func newExportWriter(format pb.ExportDealsRequest_Format, w io.Writer, columns []exportColumn) (*exportWriter, error) {
+	var cells cellWriter
+	switch format {
+	case pb.ExportDealsRequest_CSV:
+		cells = &csvCellWriter{csv.NewWriter(w)}
+	case pb.ExportDealsRequest_XLSX:
+		xlsx, err := newXlsxCellWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		cells = xlsx
+	default:
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unknown export format %v", format))
+	}
+
+	header := make([]string, len(columns))
+	numeric := make([]bool, len(columns))
+	for i, column := range columns {
+		header[i], numeric[i] = column.name, column.numeric
+	}
+	if err := cells.WriteCells(header, nil); err != nil {
+		return nil, err
+	}
+
+	return &exportWriter{cells: cells, columns: columns, numeric: numeric}, nil
+}
+
+func (w *exportWriter) WriteRow(row *exportRow) error {
+	cells := make([]string, len(w.columns))
+	for i, column := range w.columns {
+		cells[i] = column.value(row)
+	}
+	return w.cells.WriteCells(cells, w.numeric)
+}
+
+func (w *exportWriter) Close() error {
+	return w.cells.Close()
+}
+
+func exportFormatFile(format pb.ExportDealsRequest_Format) (extension string, contentType string) {
+	if format == pb.ExportDealsRequest_XLSX {
+		return "xlsx", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "csv", "text/csv"
+}
+
+type csvCellWriter struct {
+	csv *csv.Writer
+}
+
+func (w *csvCellWriter) WriteCells(cells []string, _ []bool) error {
+	return w.csv.Write(cells)
+}
+
+func (w *csvCellWriter) Close() error {
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// xlsxCellWriter writes a workbook with a single sheet. The sheet is written
+// row by row into the zip archive, which needs no seeking, and strings are
+// inline so that there is no shared strings table to keep in memory.
+type xlsxCellWriter struct {
+	archive *zip.Writer
+	sheet   *bufio.Writer
+}
+
+const xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const xlsxSheetFooter = `</sheetData></worksheet>`
+
+// xlsxParts are the parts of the workbook besides the sheet.
+var xlsxParts = []struct{ name, content string }{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Deals" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+func newXlsxCellWriter(w io.Writer) (*xlsxCellWriter, error) {
+	archive := zip.NewWriter(w)
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	writer := &xlsxCellWriter{archive: archive, sheet: bufio.NewWriter(sheet)}
+	if _, err := writer.sheet.WriteString(xlsxSheetHeader); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *xlsxCellWriter) WriteCells(cells []string, numeric []bool) error {
+	w.sheet.WriteString("<row>")
+	for i, cell := range cells {
+		if i < len(numeric) && numeric[i] && cell != "" {
+			fmt.Fprintf(w.sheet, `<c t="n"><v>%s</v></c>`, cell)
+			continue
+		}
+		w.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		if err := xml.EscapeText(w.sheet, []byte(cell)); err != nil {
+			return err
+		}
+		w.sheet.WriteString(`</t></is></c>`)
+	}
+	_, err := w.sheet.WriteString("</row>")
+	return err
+}
+
+func (w *xlsxCellWriter) Close() error {
+	if _, err := w.sheet.WriteString(xlsxSheetFooter); err != nil {
+		return err
+	}
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+
+	for _, part := range xlsxParts {
+		f, err := w.archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+	return w.archive.Close()
+}
+
+// exportChunkWriter sends everything written to it as chunks of the stream,
+// the first chunk carries the file name and content type.
+type exportChunkWriter struct {
+	stream      pb.Gandalf_ExportDealsServer
+	buf         []byte
+	fileName    string
+	contentType string
+	sent        bool
+}
+
+func newExportChunkWriter(stream pb.Gandalf_ExportDealsServer, fileName string, contentType string) *exportChunkWriter {
+	return &exportChunkWriter{
+		stream:      stream,
+		buf:         make([]byte, 0, exportChunkSize),
+		fileName:    fileName,
+		contentType: contentType,
+	}
+}
+
+func (w *exportChunkWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		free := exportChunkSize - len(w.buf)
+		if free > len(p) {
+			free = len(p)
+		}
+		w.buf, p = append(w.buf, p[:free]...), p[free:]
+		if len(w.buf) == exportChunkSize {
+			if err := w.Flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Flush sends the buffered data, the first call sends a chunk even if there
+// is no data so that the receiver gets the file name.
+func (w *exportChunkWriter) Flush() error {
+	if len(w.buf) == 0 && w.sent {
+		return nil
+	}
+
+	chunk := &pb.ExportChunk{Data: w.buf}
+	if !w.sent {
+		chunk.FileName, chunk.ContentType = w.fileName, w.contentType
+	}
+	if err := w.stream.Send(chunk); err != nil {
+		return err
+	}
+
+	w.sent = true
+	w.buf = make([]byte, 0, exportChunkSize)
+	return nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"github.com/shopspring/decimal"
+)
+
+// Fixtures is the content of a fixture file, see fixtures/dev.json.
+type Fixtures struct {
+	Symbols []FixtureSymbol `json:"symbols"`
+	Deals   []FixtureDeal   `json:"deals"`
+}
+
+type FixtureSymbol struct {
+	Symbol  string          `json:"symbol"`
+	Status  string          `json:"status"`
+	Balance decimal.Decimal `json:"balance"`
+	Limit   decimal.Decimal `json:"limit"`
+	Paper   bool            `json:"paper"`
+}
+
+type FixtureDeal struct {
+	Id             string          `json:"id"`
+	Symbol         string          `json:"symbol"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Amount         decimal.Decimal `json:"amount"`
+	AmountCurrency decimal.Decimal `json:"amount_currency"`
+	DeltaAmount    decimal.Decimal `json:"delta_amount"`
+	DeltaPercent   decimal.Decimal `json:"delta_percent"`
+	Prediction     struct {
+		Stop float32 `json:"stop"`
+		Max  float32 `json:"max"`
+	} `json:"prediction"`
+	Paper     bool            `json:"paper"`
+	OpenPrice decimal.Decimal `json:"open_price"`
+}
+
+func ReadFixtures(path string) (*Fixtures, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	decoder := json.NewDecoder(f)
+	decoder.DisallowUnknownFields()
+
+	fixtures := &Fixtures{}
+	if err := decoder.Decode(fixtures); err != nil {
+		return nil, errors.New(fmt.Sprintf("can't parse fixtures %s: %v", path, err))
+	}
+
+	return fixtures, nil
+}
+
+func (f *Fixtures) tradingSymbols() ([]*TradingSymbol, error) {
+	symbols := make([]*TradingSymbol, 0, len(f.Symbols))
+	for _, s := range f.Symbols {
+		status, ok := pb.TradingSymbol_TradingStatus_value[s.Status]
+		if !ok {
+			return nil, errors.New(fmt.Sprintf("unknown status '%s' of fixture symbol '%s'", s.Status, s.Symbol))
+		}
+
+		symbols = append(symbols, &TradingSymbol{
+			Symbol:  s.Symbol,
+			Status:  pb.TradingSymbol_TradingStatus(status),
+			Balance: s.Balance,
+			Limit:   s.Limit,
+			Paper:   s.Paper,
+			Config:  newSymbolConfigVersion(defaultSymbolConfig(), 1, 0),
+			Version: 1,
+		})
+	}
+	return symbols, nil
+}
+
+func (f *Fixtures) deals() []*Deal {
+	deals := make([]*Deal, 0, len(f.Deals))
+	for _, d := range f.Deals {
+		createdAt := d.CreatedAt
+		if createdAt.IsZero() {
+			createdAt = time.Now()
+		}
+
+		deals = append(deals, &Deal{
+			Id:             d.Id,
+			Symbol:         d.Symbol,
+			CreatedAt:      createdAt,
+			Amount:         d.Amount,
+			AmountCurrency: d.AmountCurrency,
+			DeltaAmount:    d.DeltaAmount,
+			DeltaPercent:   d.DeltaPercent,
+			Prediction:     DealPrediction{d.Prediction.Stop, d.Prediction.Max},
+			Paper:          d.Paper,
+			OpenPrice:      d.OpenPrice,
+			Version:        1,
+		})
+	}
+	return deals
+}
@@ -0,0 +1,613 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// gatewayRoute maps a REST path to a method of the Gandalf service. Path
+// parameters in braces and query parameters are matched to request fields by
+// their JSON names, the body of POST and PUT requests is the request message.
+type gatewayRoute struct {
+	Method string
+	Path   string
+	Rpc    string
+}
+
+var gatewayRoutes = []gatewayRoute{
+	{http.MethodGet, "/v1/symbols", "GetTradingSymbols"},
+	{http.MethodPost, "/v1/symbols/{symbol}/prepare", "SymbolTradingPrepare"},
+	{http.MethodPost, "/v1/symbols/{symbol}/start", "SymbolTradingStart"},
+	{http.MethodPost, "/v1/symbols/{symbol}/stop", "SymbolTradingStop"},
+	{http.MethodPost, "/v1/symbols/{symbol}/suspend", "SymbolTradingSuspend"},
+	{http.MethodPost, "/v1/symbols/{symbol}/resume", "SymbolTradingResume"},
+	{http.MethodGet, "/v1/balances", "GetSymbolBalances"},
+	{http.MethodGet, "/v1/balances/history", "GetBalanceHistory"},
+	{http.MethodGet, "/v1/limits", "GetSymbolLimits"},
+	{http.MethodPut, "/v1/limits", "SetSymbolLimits"},
+	{http.MethodGet, "/v1/deals", "GetActiveDeals"},
+	{http.MethodGet, "/v1/deals/potential", "GetPotentialDeals"},
+	{http.MethodGet, "/v1/deals/export", "ExportDeals"},
+	{http.MethodPost, "/v1/deals/close", "CloseDeals"},
+	{http.MethodPost, "/v1/paper/deals", "OpenPaperDeal"},
+	{http.MethodGet, "/v1/subscription", "GetSubscription"},
+	{http.MethodPut, "/v1/subscription", "SetSubscription"},
+	{http.MethodPost, "/v1/halt", "EmergencyHalt"},
+	{http.MethodPost, "/v1/halt/resume", "EmergencyResume"},
+	{http.MethodGet, "/v1/symbols/{symbol}/config", "GetSymbolConfig"},
+	{http.MethodPut, "/v1/symbols/{symbol}/config", "SetSymbolConfig"},
+	{http.MethodGet, "/v1/predictions/stats", "GetPredictionStats"},
+	{http.MethodGet, "/v1/schedules", "ListSchedules"},
+	{http.MethodPost, "/v1/schedules", "ScheduleStatusChange"},
+	{http.MethodPost, "/v1/schedules/{scheduleId}/cancel", "CancelSchedule"},
+	{http.MethodGet, "/v1/approvals", "ListPendingApprovals"},
+	{http.MethodPost, "/v1/approvals/{approvalId}/approve", "Approve"},
+	{http.MethodPost, "/v1/approvals/{approvalId}/reject", "Reject"},
+}
+
+const (
+	gatewayMaxBody   = 1 << 20
+	gatewayRpcPrefix = "/" + gandalfServiceName + "/"
+)
+
+var errGatewayUnauthenticated = status.Error(codes.Unauthenticated, "missing or unknown bearer token")
+
+// Gateway serves the Gandalf service as HTTP/JSON. Calls go through the same
+// interceptors and Server methods as gRPC calls, so auth and validation are
+// shared. Callers authenticate with a bearer token, the user id of the token
+// is the user id of the request whatever the caller sends.
+type Gateway struct {
+	logger             *zap.SugaredLogger
+	server             pb.GandalfServer
+	interceptors       []grpc.UnaryServerInterceptor
+	streamInterceptors []grpc.StreamServerInterceptor
+	tokens             map[[sha256.Size]byte]int64
+	routes             []gatewayRoute
+	spec               []byte
+}
+
+// NewGateway takes the tokens of the callers as "token:userId" pairs
+// separated by commas.
+func NewGateway(
+	logger *zap.SugaredLogger,
+	server pb.GandalfServer,
+	interceptors []grpc.UnaryServerInterceptor,
+	streamInterceptors []grpc.StreamServerInterceptor,
+	tokens string,
+) (*Gateway, error) {
+	userTokens, err := parseGatewayTokens(tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	methods := pb.File_pb_service_proto.Services().ByName("Gandalf").Methods()
+	routed := make(map[string]bool)
+	for _, route := range gatewayRoutes {
+		method := methods.ByName(protoreflect.Name(route.Rpc))
+		if method == nil {
+			return nil, errors.New(fmt.Sprintf("gateway route %s %s: unknown method %s", route.Method, route.Path, route.Rpc))
+		}
+		if method.IsStreamingServer() && gatewayStreams[route.Rpc] == nil {
+			return nil, errors.New(fmt.Sprintf("gateway route %s %s: no stream for method %s", route.Method, route.Path, route.Rpc))
+		}
+		routed[route.Rpc] = true
+	}
+	for i := 0; i < methods.Len(); i++ {
+		if !routed[string(methods.Get(i).Name())] {
+			return nil, errors.New(fmt.Sprintf("gateway: no route for method %s", methods.Get(i).Name()))
+		}
+	}
+
+	spec, err := json.MarshalIndent(OpenApiSpec(gatewayRoutes), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Gateway{
+		logger:             logger,
+		server:             server,
+		interceptors:       interceptors,
+		streamInterceptors: streamInterceptors,
+		tokens:             userTokens,
+		routes:             gatewayRoutes,
+		spec:               spec,
+	}, nil
+}
+
+// parseGatewayTokens keeps the hashes of the tokens, so that looking a token
+// up doesn't leak it through timing.
+func parseGatewayTokens(src string) (map[[sha256.Size]byte]int64, error) {
+	tokens := make(map[[sha256.Size]byte]int64)
+	for _, pair := range strings.Split(src, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		i := strings.LastIndex(pair, ":")
+		if i <= 0 {
+			return nil, errors.New("gateway tokens must be token:userId pairs")
+		}
+		userId, err := strconv.ParseInt(pair[i+1:], 10, 64)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("invalid user id of a gateway token: %v", err))
+		}
+		tokens[sha256.Sum256([]byte(pair[:i]))] = userId
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("the gateway needs at least one token")
+	}
+	return tokens, nil
+}
+
+// authenticate returns the user id of the bearer token of the request.
+func (g *Gateway) authenticate(r *http.Request) (int64, error) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == r.Header.Get("Authorization") {
+		return 0, errGatewayUnauthenticated
+	}
+	userId, ok := g.tokens[sha256.Sum256([]byte(token))]
+	if !ok {
+		return 0, errGatewayUnauthenticated
+	}
+	return userId, nil
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet && r.URL.Path == "/openapi.json" {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(g.spec)
+		return
+	}
+
+	pathMatched := false
+	for _, route := range g.routes {
+		params, ok := matchPath(route.Path, r.URL.Path)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if route.Method != r.Method {
+			continue
+		}
+
+		g.handle(w, r, route, params)
+		return
+	}
+
+	if pathMatched {
+		writeGatewayError(w, http.StatusMethodNotAllowed, codes.Unimplemented, "method not allowed")
+		return
+	}
+	writeGatewayError(w, http.StatusNotFound, codes.NotFound, "not found")
+}
+
+func (g *Gateway) handle(w http.ResponseWriter, r *http.Request, route gatewayRoute, params map[string]string) {
+	userId, err := g.authenticate(r)
+	if err != nil {
+		writeGatewayError(w, http.StatusUnauthorized, codes.Unauthenticated, status.Convert(err).Message())
+		return
+	}
+
+	if gatewayStreams[route.Rpc] != nil {
+		g.handleStream(w, r, route, params, userId)
+		return
+	}
+
+	method := reflect.ValueOf(g.server).MethodByName(route.Rpc)
+	req := reflect.New(method.Type().In(1).Elem()).Interface().(proto.Message)
+
+	if err := g.decodeRequest(r, req, params, userId); err != nil {
+		writeGatewayError(w, http.StatusBadRequest, codes.InvalidArgument, err.Error())
+		return
+	}
+
+	ctx := metadata.NewIncomingContext(r.Context(), headersToMetadata(r.Header))
+	resp, err := invokeServer(ctx, g.server, g.interceptors, route.Rpc, req)
+	if err != nil {
+		st := status.Convert(err)
+		setRetryAfter(w, st)
+		writeGatewayError(w, httpStatusFromCode(st.Code()), st.Code(), st.Message())
+		return
+	}
+
+	body, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(resp)
+	if err != nil {
+		writeGatewayError(w, http.StatusInternalServerError, codes.Internal, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// decodeRequest fills the request from the body, the query and the path, the
+// user id is always the authenticated one.
+func (g *Gateway) decodeRequest(r *http.Request, req proto.Message, params map[string]string, userId int64) error {
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		body, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, gatewayMaxBody))
+		if err != nil {
+			return err
+		}
+		if len(body) > 0 {
+			if err := protojson.Unmarshal(body, req); err != nil {
+				return err
+			}
+		}
+	}
+
+	msg := req.ProtoReflect()
+	for name, values := range r.URL.Query() {
+		if err := setGatewayField(msg, name, values); err != nil {
+			return err
+		}
+	}
+	for name, value := range params {
+		if err := setGatewayField(msg, name, []string{value}); err != nil {
+			return err
+		}
+	}
+	if fd := msg.Descriptor().Fields().ByJSONName("userId"); fd != nil {
+		msg.Set(fd, protoreflect.ValueOfInt64(userId))
+	}
+
+	return nil
+}
+
+// setGatewayField sets a scalar or repeated field of the request from string values.
+func setGatewayField(msg protoreflect.Message, name string, values []string) error {
+	fd := msg.Descriptor().Fields().ByJSONName(name)
+	if fd == nil {
+		return errors.New(fmt.Sprintf("unknown parameter '%s'", name))
+	}
+
+	if fd.IsList() {
+		list := msg.Mutable(fd).List()
+		for _, value := range values {
+			v, err := parseGatewayValue(fd, value)
+			if err != nil {
+				return err
+			}
+			list.Append(v)
+		}
+		return nil
+	}
+
+	if len(values) != 1 {
+		return errors.New(fmt.Sprintf("parameter '%s' must have a single value", name))
+	}
+
+	v, err := parseGatewayValue(fd, values[0])
+	if err != nil {
+		return err
+	}
+	msg.Set(fd, v)
+
+	return nil
+}
+
+func parseGatewayValue(fd protoreflect.FieldDescriptor, value string) (protoreflect.Value, error) {
+	invalid := func(err error) (protoreflect.Value, error) {
+		return protoreflect.Value{}, errors.New(fmt.Sprintf("invalid value of '%s': %v", fd.JSONName(), err))
+	}
+
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(value), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfBool(b), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfInt32(int32(n)), nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfInt64(n), nil
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfFloat32(float32(f)), nil
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return invalid(err)
+		}
+		return protoreflect.ValueOfFloat64(f), nil
+	case protoreflect.EnumKind:
+		ev := fd.Enum().Values().ByName(protoreflect.Name(value))
+		if ev == nil {
+			return invalid(errors.New("unknown enum value"))
+		}
+		return protoreflect.ValueOfEnum(ev.Number()), nil
+	case protoreflect.MessageKind:
+		if fd.Message().FullName() == "google.protobuf.Timestamp" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return invalid(err)
+			}
+			return protoreflect.ValueOfMessage(timestamppb.New(t).ProtoReflect()), nil
+		}
+	}
+
+	return invalid(errors.New("not supported as a parameter"))
+}
+
+// matchPath matches a path against a route pattern and returns its parameters.
+func matchPath(pattern, path string) (map[string]string, bool) {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternParts) != len(pathParts) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, part := range patternParts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			if pathParts[i] == "" {
+				return nil, false
+			}
+			params[strings.Trim(part, "{}")] = pathParts[i]
+			continue
+		}
+		if part != pathParts[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+// invokeServer calls a method of the server by name through the interceptors,
+// the same way the grpc server handles a call of it.
+func invokeServer(
+	ctx context.Context,
+	server pb.GandalfServer,
+	interceptors []grpc.UnaryServerInterceptor,
+	rpc string,
+	req proto.Message,
+) (proto.Message, error) {
+	method := reflect.ValueOf(server).MethodByName(rpc)
+	if !method.IsValid() {
+		return nil, status.Error(codes.Unimplemented, fmt.Sprintf("unknown method %s", rpc))
+	}
+
+	info := &grpc.UnaryServerInfo{Server: server, FullMethod: gatewayRpcPrefix + rpc}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		out := method.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(req)})
+		if err, _ := out[1].Interface().(error); err != nil {
+			return nil, err
+		}
+		return out[0].Interface(), nil
+	}
+
+	resp, err := chainInterceptors(interceptors, info, handler)(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.(proto.Message), nil
+}
+
+// chainInterceptors builds the handler the grpc server would run for a call.
+func chainInterceptors(
+	interceptors []grpc.UnaryServerInterceptor,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) grpc.UnaryHandler {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, req interface{}) (interface{}, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+	return handler
+}
+
+// headersToMetadata passes the headers on to the interceptors, except for the
+// token of the caller.
+func headersToMetadata(header http.Header) metadata.MD {
+	md := metadata.MD{}
+	for name, values := range header {
+		if strings.EqualFold(name, "Authorization") {
+			continue
+		}
+		md.Append(strings.ToLower(name), values...)
+	}
+	return md
+}
+
+// setRetryAfter copies the retry delay of a status, e.g. of a rate limited
+// call, to the Retry-After header.
+func setRetryAfter(w http.ResponseWriter, st *status.Status) {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.RetryDelay != nil {
+			seconds := int64(math.Ceil(info.RetryDelay.AsDuration().Seconds()))
+			w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+		}
+	}
+}
+
+func writeGatewayError(w http.ResponseWriter, httpStatus int, code codes.Code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"code":    code.String(),
+		"message": message,
+	})
+}
+
+// httpStatusFromCode maps grpc codes the same way grpc-gateway does.
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// gatewayStreams adapts the stream of the gateway to the stream types of the
+// server streaming methods, which are the only ones the gateway supports.
+// Their messages must be chunks of a file.
+var gatewayStreams = map[string]func(grpc.ServerStream) interface{}{
+	"ExportDeals": func(stream grpc.ServerStream) interface{} { return &gatewayExportDealsStream{stream} },
+}
+
+type gatewayExportDealsStream struct {
+	grpc.ServerStream
+}
+
+func (s *gatewayExportDealsStream) Send(chunk *pb.ExportChunk) error {
+	return s.SendMsg(chunk)
+}
+
+// handleStream writes the chunks of a server streaming method as the response
+// body. Errors after the first chunk can't change the status anymore, so the
+// connection is aborted to let the client know the file is incomplete.
+func (g *Gateway) handleStream(w http.ResponseWriter, r *http.Request, route gatewayRoute, params map[string]string, userId int64) {
+	method := reflect.ValueOf(g.server).MethodByName(route.Rpc)
+	req := reflect.New(method.Type().In(0).Elem()).Interface().(proto.Message)
+
+	if err := g.decodeRequest(r, req, params, userId); err != nil {
+		writeGatewayError(w, http.StatusBadRequest, codes.InvalidArgument, err.Error())
+		return
+	}
+
+	stream := &gatewayStream{
+		ctx: metadata.NewIncomingContext(r.Context(), headersToMetadata(r.Header)),
+		w:   w,
+		req: req,
+	}
+	info := &grpc.StreamServerInfo{FullMethod: gatewayRpcPrefix + route.Rpc, IsServerStream: true}
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		in := reflect.New(method.Type().In(0).Elem()).Interface().(proto.Message)
+		if err := ss.RecvMsg(in); err != nil {
+			return err
+		}
+		out := method.Call([]reflect.Value{reflect.ValueOf(in), reflect.ValueOf(gatewayStreams[route.Rpc](ss))})
+		err, _ := out[0].Interface().(error)
+		return err
+	}
+
+	for i := len(g.streamInterceptors) - 1; i >= 0; i-- {
+		interceptor, next := g.streamInterceptors[i], handler
+		handler = func(srv interface{}, ss grpc.ServerStream) error {
+			return interceptor(srv, ss, info, next)
+		}
+	}
+
+	if err := handler(g.server, stream); err != nil {
+		if stream.written {
+			g.logger.Errorf("gateway stream %s failed after the first chunk: %v", route.Rpc, err)
+			panic(http.ErrAbortHandler)
+		}
+		st := status.Convert(err)
+		setRetryAfter(w, st)
+		writeGatewayError(w, httpStatusFromCode(st.Code()), st.Code(), st.Message())
+	}
+}
+
+// gatewayStream is the server side of a stream whose request is decoded from
+// the http request and whose messages are written to the response.
+type gatewayStream struct {
+	ctx      context.Context
+	w        http.ResponseWriter
+	req      proto.Message
+	received bool
+	written  bool
+}
+
+func (s *gatewayStream) SetHeader(metadata.MD) error  { return nil }
+func (s *gatewayStream) SendHeader(metadata.MD) error { return nil }
+func (s *gatewayStream) SetTrailer(metadata.MD)       {}
+func (s *gatewayStream) Context() context.Context     { return s.ctx }
+
+func (s *gatewayStream) RecvMsg(m interface{}) error {
+	if s.received {
+		return io.EOF
+	}
+	s.received = true
+	proto.Merge(m.(proto.Message), s.req)
+	return nil
+}
+
+func (s *gatewayStream) SendMsg(m interface{}) error {
+	chunk, ok := m.(*pb.ExportChunk)
+	if !ok {
+		return status.Error(codes.Internal, fmt.Sprintf("gateway can't stream %T", m))
+	}
+
+	if !s.written {
+		contentType := chunk.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		s.w.Header().Set("Content-Type", contentType)
+		if chunk.FileName != "" {
+			s.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", chunk.FileName))
+		}
+		s.written = true
+	}
+
+	if _, err := s.w.Write(chunk.Data); err != nil {
+		return err
+	}
+	if flusher, ok := s.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
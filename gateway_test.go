@@ -0,0 +1,67 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestGatewayAuthentication(t *testing.T) {
+	server := &fakeBotServer{}
+	gateway, err := NewGateway(zap.NewNop().Sugar(), server, nil, nil, "secret:7, other:8")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		path          string
+		body          string
+		status        int
+		calls         []string
+	}{
+		{"no token", "", "/v1/symbols/adausdt/suspend", "", http.StatusUnauthorized, nil},
+		{"unknown token", "Bearer guess", "/v1/symbols/adausdt/suspend", "", http.StatusUnauthorized, nil},
+		{"token without scheme", "secret", "/v1/symbols/adausdt/suspend", "", http.StatusUnauthorized, nil},
+		{"token", "Bearer secret", "/v1/symbols/adausdt/suspend", "", http.StatusOK, []string{"SymbolTradingSuspend adausdt by 7"}},
+		{"user id in the body", "Bearer other", "/v1/symbols/adausdt/suspend", `{"userId": "7"}`, http.StatusOK, []string{"SymbolTradingSuspend adausdt by 8"}},
+		{"user id in the query", "Bearer other", "/v1/symbols/adausdt/suspend?userId=7", "", http.StatusOK, []string{"SymbolTradingSuspend adausdt by 8"}},
+	}
+
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodPost, test.path, strings.NewReader(test.body))
+		if test.authorization != "" {
+			r.Header.Set("Authorization", test.authorization)
+		}
+		w := httptest.NewRecorder()
+		gateway.ServeHTTP(w, r)
+
+		if w.Code != test.status {
+			t.Errorf("%s: status %d, want %d: %s", test.name, w.Code, test.status, w.Body.String())
+		}
+		calls := server.takeCalls()
+		if strings.Join(calls, "\n") != strings.Join(test.calls, "\n") {
+			t.Errorf("%s: calls %q, want %q", test.name, calls, test.calls)
+		}
+	}
+}
+
+func TestParseGatewayTokens(t *testing.T) {
+	for _, src := range []string{"", " , ", "secret", ":7", "secret:user"} {
+		if _, err := parseGatewayTokens(src); err == nil {
+			t.Errorf("%q: no error", src)
+		}
+	}
+
+	tokens, err := parseGatewayTokens("a:b:1,c:2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 2 {
+		t.Errorf("%d tokens, want 2", len(tokens))
+	}
+}
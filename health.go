@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthPb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const gandalfServiceName = "gandalf.Gandalf"
+
+// HealthChecker reports the service as NOT_SERVING through the standard grpc
+// health service while Mongo is unreachable.
+type HealthChecker struct {
+	logger   *zap.SugaredLogger
+	storage  *Storage
+	server   *health.Server
+	interval time.Duration
+}
+
+func NewHealthChecker(
+	logger *zap.SugaredLogger,
+	storage *Storage,
+	interval time.Duration,
+) *HealthChecker {
+	return &HealthChecker{
+		logger:   logger,
+		storage:  storage,
+		server:   health.NewServer(),
+		interval: interval,
+	}
+}
+
+func (h *HealthChecker) Server() *health.Server {
+	return h.server
+}
+
+// Run checks Mongo every interval until ctx is done.
+func (h *HealthChecker) Run(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	h.check(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.check(ctx)
+		}
+	}
+}
+
+// Shutdown makes all services NOT_SERVING, so that clients stop sending
+// requests while the server drains.
+func (h *HealthChecker) Shutdown() {
+	h.server.Shutdown()
+}
+
+func (h *HealthChecker) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, h.interval)
+	defer cancel()
+
+	status := healthPb.HealthCheckResponse_SERVING
+	if err := h.storage.Ping(ctx); err != nil {
+		if ctx.Err() == context.Canceled {
+			return
+		}
+		h.logger.Errorf("mongo is unreachable: %v", err)
+		status = healthPb.HealthCheckResponse_NOT_SERVING
+	}
+
+	h.server.SetServingStatus("", status)
+	h.server.SetServingStatus(gandalfServiceName, status)
+}
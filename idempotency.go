@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	idempotencyKeyHeader      = "idempotency-key"
+	idempotencyReplayedHeader = "idempotency-replayed"
+)
+
+var (
+	errIdempotencyKeyReused = status.Error(codes.InvalidArgument, "idempotency key was used for a different request")
+	errIdempotencyInFlight  = status.Error(codes.Aborted, "request with the same idempotency key is in progress")
+)
+
+// readOnlyRpcs are the rpcs that change nothing, every other rpc, including
+// new ones until they are added here, is treated as mutating. ListPendingApprovals
+// isn't one of them, it expires and reconciles approvals.
+var readOnlyRpcs = map[string]bool{
+	"GetTradingSymbols":  true,
+	"GetSymbolBalances":  true,
+	"GetBalanceHistory":  true,
+	"GetSymbolLimits":    true,
+	"GetActiveDeals":     true,
+	"GetPotentialDeals":  true,
+	"GetSubscription":    true,
+	"GetSymbolConfig":    true,
+	"GetPredictionStats": true,
+	"ListSchedules":      true,
+	"ExportDeals":        true,
+}
+
+func isReadOnlyRpc(fullMethod string) bool {
+	return readOnlyRpcs[fullMethod[strings.LastIndex(fullMethod, "/")+1:]]
+}
+
+// IdempotencyInterceptor makes retries of mutating rpcs safe. The first
+// successful response to a key is stored for ttl and returned again for
+// requests with the same key, a key reused for another request is rejected.
+// Failed calls are not stored, so they may be retried with the same key,
+// except for calls kept as pending approvals, their error is replayed instead
+// of requesting another approval. A call holds the key for the lease, if it
+// doesn't finish within it, e.g. because the service crashed, a retry takes
+// the key over.
+func IdempotencyInterceptor(logger *zap.SugaredLogger, storage *Storage, ttl time.Duration, lease time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		keys := md.Get(idempotencyKeyHeader)
+		if len(keys) == 0 || keys[0] == "" || isReadOnlyRpc(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		message, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		body, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+		if err != nil {
+			return nil, err
+		}
+		hash := sha256.Sum256(append([]byte(info.FullMethod+"\n"), body...))
+
+		var userId int64
+		if r, ok := req.(userRequest); ok {
+			userId = r.GetUserId()
+		}
+
+		now := time.Now()
+		record := &IdempotencyRecord{
+			Id:          fmt.Sprintf("%d:%s", userId, keys[0]),
+			RequestHash: hex.EncodeToString(hash[:]),
+			CreatedAt:   now,
+			LockedUntil: now.Add(lease),
+			ExpiresAt:   now.Add(ttl),
+		}
+		reserved, err := storage.ReserveIdempotencyKey(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+		if !reserved {
+			return replayIdempotent(ctx, storage, record)
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil && !isApprovalPending(err) {
+			if err := storage.DeleteIdempotencyKey(ctx, record.Id); err != nil {
+				loggerFromContext(ctx, logger).Errorf("cannot release idempotency key %s: %v", record.Id, err)
+			}
+			return resp, err
+		}
+
+		// the status of a pending approval is stored as the response
+		respMessage, ok := resp.(proto.Message)
+		if err != nil {
+			respMessage, ok = status.Convert(err).Proto(), true
+		}
+		if ok {
+			body, err := proto.Marshal(respMessage)
+			if err == nil {
+				err = storage.CompleteIdempotencyKey(ctx, record.Id, string(respMessage.ProtoReflect().Descriptor().FullName()), body)
+			}
+			if err != nil {
+				loggerFromContext(ctx, logger).Errorf("cannot store response of idempotency key %s: %v", record.Id, err)
+			}
+		}
+
+		return resp, err
+	}
+}
+
+func replayIdempotent(ctx context.Context, storage *Storage, record *IdempotencyRecord) (interface{}, error) {
+	stored, err := storage.GetIdempotencyKey(ctx, record.Id)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		// released by a failed call or expired after the insert, the client retries
+		return nil, errIdempotencyInFlight
+	}
+	if stored.RequestHash != record.RequestHash {
+		return nil, errIdempotencyKeyReused
+	}
+	if stored.ResponseType == "" {
+		return nil, errIdempotencyInFlight
+	}
+
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(stored.ResponseType))
+	if err != nil {
+		return nil, err
+	}
+	resp := messageType.New().Interface()
+	if err := proto.Unmarshal(stored.Response, resp); err != nil {
+		return nil, err
+	}
+
+	setResponseHeader(ctx, idempotencyReplayedHeader, "true")
+	if st, ok := resp.(*spb.Status); ok {
+		return nil, status.ErrorProto(st)
+	}
+	return resp, nil
+}
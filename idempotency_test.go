@@ -0,0 +1,33 @@
+package main
+
+import (
+	"testing"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+func TestReadOnlyRpcs(t *testing.T) {
+	methods := pb.File_pb_service_proto.Services().ByName("Gandalf").Methods()
+	for name := range readOnlyRpcs {
+		if methods.ByName(protoreflect.Name(name)) == nil {
+			t.Errorf("unknown read-only method %s", name)
+		}
+	}
+
+	tests := []struct {
+		method   string
+		readOnly bool
+	}{
+		{gatewayRpcPrefix + "GetTradingSymbols", true},
+		{gatewayRpcPrefix + "ExportDeals", true},
+		{gatewayRpcPrefix + "ListPendingApprovals", false},
+		{gatewayRpcPrefix + "SetSymbolLimits", false},
+		{gatewayRpcPrefix + "GetUnknown", false},
+	}
+	for _, test := range tests {
+		if readOnly := isReadOnlyRpc(test.method); readOnly != test.readOnly {
+			t.Errorf("%s: read-only %v, want %v", test.method, readOnly, test.readOnly)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const requestIdHeader = "x-request-id"
+
+type loggerKey struct{}
+
+// userRequest is implemented by every request message of the service.
+type userRequest interface {
+	GetUserId() int64
+}
+
+// NewLogger builds a production logger writing JSON or console formatted
+// entries of the given level and above.
+func NewLogger(level, format string) (*zap.SugaredLogger, error) {
+	var zapLevel zapcore.Level
+	if err := zapLevel.UnmarshalText([]byte(level)); err != nil {
+		return nil, errors.New(fmt.Sprintf("invalid log level '%s'", level))
+	}
+
+	config := zap.NewProductionConfig()
+	config.Level = zap.NewAtomicLevelAt(zapLevel)
+	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	switch format {
+	case "json":
+	case "console":
+		config.Encoding = "console"
+		config.EncoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+	default:
+		return nil, errors.New(fmt.Sprintf("invalid log format '%s'", format))
+	}
+
+	logger, err := config.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return logger.Sugar(), nil
+}
+
+// LoggingInterceptor logs every call with its method, user, duration and
+// status. The request id is taken from the x-request-id metadata or generated,
+// returned in the response header and attached to the logger in the context.
+func LoggingInterceptor(logger *zap.SugaredLogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		requestId := incomingRequestId(ctx)
+		setResponseHeader(ctx, requestIdHeader, requestId)
+
+		reqLogger := logger.With("request_id", requestId)
+		ctx = context.WithValue(ctx, loggerKey{}, reqLogger)
+
+		resp, err := handler(ctx, req)
+
+		fields := []interface{}{
+			"method", info.FullMethod,
+			"duration", time.Since(start),
+			"code", status.Code(err).String(),
+		}
+		if r, ok := req.(userRequest); ok {
+			fields = append(fields, "user_id", r.GetUserId())
+		}
+
+		switch status.Code(err) {
+		case codes.OK:
+			reqLogger.Infow("request handled", fields...)
+		case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable:
+			reqLogger.Errorw("request failed", append(fields, "error", err)...)
+		default:
+			reqLogger.Warnw("request rejected", append(fields, "error", err)...)
+		}
+
+		return resp, err
+	}
+}
+
+// LoggingStreamInterceptor is LoggingInterceptor for streaming calls, the user
+// is taken from the request message of the stream.
+func LoggingStreamInterceptor(logger *zap.SugaredLogger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		requestId := incomingRequestId(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(requestIdHeader, requestId))
+
+		reqLogger := logger.With("request_id", requestId)
+		var userId *int64
+		stream := &wrappedStream{
+			ServerStream: ss,
+			ctx:          context.WithValue(ss.Context(), loggerKey{}, reqLogger),
+			onRecv: func(m interface{}) error {
+				if r, ok := m.(userRequest); ok && userId == nil {
+					id := r.GetUserId()
+					userId = &id
+				}
+				return nil
+			},
+		}
+
+		err := handler(srv, stream)
+
+		fields := []interface{}{
+			"method", info.FullMethod,
+			"duration", time.Since(start),
+			"code", status.Code(err).String(),
+		}
+		if userId != nil {
+			fields = append(fields, "user_id", *userId)
+		}
+
+		switch status.Code(err) {
+		case codes.OK:
+			reqLogger.Infow("stream handled", fields...)
+		case codes.Unknown, codes.Internal, codes.DataLoss, codes.Unavailable:
+			reqLogger.Errorw("stream failed", append(fields, "error", err)...)
+		default:
+			reqLogger.Warnw("stream rejected", append(fields, "error", err)...)
+		}
+
+		return err
+	}
+}
+
+// wrappedStream replaces the context of a stream and lets interceptors see,
+// and reject, the received messages.
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	onRecv func(m interface{}) error
+}
+
+func (s *wrappedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *wrappedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.onRecv != nil {
+		return s.onRecv(m)
+	}
+	return nil
+}
+
+// setResponseHeader sets a header of the grpc response. Outside of a grpc call,
+// e.g. in the gateway, there is no header to set and it does nothing.
+func setResponseHeader(ctx context.Context, name, value string) {
+	_ = grpc.SetHeader(ctx, metadata.Pairs(name, value))
+}
+
+// loggerFromContext returns the request scoped logger or the fallback when
+// called outside of a request.
+func loggerFromContext(ctx context.Context, fallback *zap.SugaredLogger) *zap.SugaredLogger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.SugaredLogger); ok {
+		return logger
+	}
+	return fallback
+}
+
+func incomingRequestId(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIdHeader); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -2,9 +2,16 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
@@ -12,17 +19,55 @@ import (
 
 	gandalfPb "github.com/mikevel2955/gandalf/pb"
 	utils "github.com/mikevel2955/hermes-utils"
+	"github.com/shopspring/decimal"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	healthPb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 type appConfig struct {
-	Addr          string `env:"GRPC_ADDR" def:":40001"`
-	UserOperators string `env:"USER_OPERATORS_LIST"`
-	UserViewers   string `env:"USER_VIEWERS_LIST"`
-	MongoDSN      string `env:"MONGO_DSN" def:"mongodb://127.0.0.1:27017"`
-	MongoDBName   string `env:"MONGO_DB_NAME" def:"gandalf_db"`
-	MongoInitDB   string `env:"MONGO_INIT_DB" def:"false"`
+	Addr          string        `env:"GRPC_ADDR" def:":40001"`
+	LogLevel      string        `env:"LOG_LEVEL" def:"info"`
+	LogFormat     string        `env:"LOG_FORMAT" def:"json"`
+	UserOperators string        `env:"USER_OPERATORS_LIST"`
+	UserViewers   string        `env:"USER_VIEWERS_LIST"`
+	MongoDSN      string        `env:"MONGO_DSN" def:"mongodb://127.0.0.1:27017"`
+	MongoDBName   string        `env:"MONGO_DB_NAME" def:"gandalf_db"`
+	MongoFixtures string        `env:"MONGO_FIXTURES"`
+	HttpAddr      string        `env:"HTTP_ADDR"`
+	HttpTokens    string        `env:"HTTP_TOKENS"`
+	MetricsAddr   string        `env:"METRICS_ADDR" def:":9090"`
+	Reflection    string        `env:"GRPC_REFLECTION" def:"false"`
+	HealthCheck   time.Duration `env:"HEALTH_CHECK_INTERVAL" def:"5s"`
+	StopTimeout   time.Duration `env:"SHUTDOWN_TIMEOUT" def:"30s"`
+	Tracing       string        `env:"TRACING_ENABLED" def:"false"`
+	TelegramToken string        `env:"TELEGRAM_TOKEN"`
+	TelegramUrl   string        `env:"TELEGRAM_API_URL" def:"https://api.telegram.org"`
+	TraceFile     string        `env:"TRACE_FILE"`
+	MongoMigrate  string        `env:"MONGO_MIGRATE" def:"true"`
+	PriceSource   string        `env:"PRICE_SOURCE" def:"huobi"`
+	HuobiApiUrl   string        `env:"HUOBI_API_URL" def:"https://api.huobi.pro"`
+	PriceReplay   string        `env:"PRICE_REPLAY_FILE"`
+	WebhookSecret string        `env:"NOTIFY_WEBHOOK_SECRET"`
+	WebhookHosts  string        `env:"NOTIFY_WEBHOOK_HOSTS"`
+	NotifyLog     string        `env:"NOTIFY_LOG_FILE"`
+	Drawdown      time.Duration `env:"DRAWDOWN_WINDOW" def:"24h"`
+	DrawdownChk   time.Duration `env:"DRAWDOWN_CHECK_INTERVAL" def:"1m"`
+	MaxDrawdown   string        `env:"PORTFOLIO_MAX_DRAWDOWN"`
+	SchedulerTick time.Duration `env:"SCHEDULER_INTERVAL" def:"10s"`
+	ExcursionTick time.Duration `env:"EXCURSION_INTERVAL" def:"10s"`
+	IdempotentTTL time.Duration `env:"IDEMPOTENCY_TTL" def:"24h"`
+	IdempotLease  time.Duration `env:"IDEMPOTENCY_LEASE" def:"1m"`
+	ReadRate      float64       `env:"RATE_LIMIT_READ_RPS" def:"10"`
+	ReadBurst     int           `env:"RATE_LIMIT_READ_BURST" def:"20"`
+	MutateRate    float64       `env:"RATE_LIMIT_MUTATE_RPS" def:"2"`
+	MutateBurst   int           `env:"RATE_LIMIT_MUTATE_BURST" def:"5"`
+	SnapshotTick  time.Duration `env:"BALANCE_SNAPSHOT_INTERVAL" def:"5m"`
+	SnapshotQuote string        `env:"BALANCE_SNAPSHOT_QUOTE" def:"usdt"`
+	ApprovalRpcs  string        `env:"APPROVAL_RPCS"`
+	ApprovalTTL   time.Duration `env:"APPROVAL_TTL" def:"15m"`
 }
 
 const (
@@ -30,15 +75,48 @@ const (
 )
 
 func main() {
-	logger := zap.NewExample().Sugar() // @FixMe use zap.New() to construct production-like logger
-
 	config := appConfig{}
 	if err := utils.ReadConfig(&config); err != nil {
-		logger.Fatal(err)
+		log.Fatal(err)
+	}
+
+	logger, err := NewLogger(config.LogLevel, config.LogFormat)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer logger.Sync()
+
+	metrics := NewMetrics()
+	mongoOptions := options.Client().ApplyURI(config.MongoDSN).SetRegistry(newBsonRegistry())
+	rateLimiter := NewRateLimiter(
+		metrics,
+		RateLimit{Rate: config.ReadRate, Burst: config.ReadBurst},
+		RateLimit{Rate: config.MutateRate, Burst: config.MutateBurst},
+	)
+	interceptors := []grpc.UnaryServerInterceptor{
+		LoggingInterceptor(logger),
+		metrics.UnaryServerInterceptor(),
+		rateLimiter.UnaryServerInterceptor(),
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		LoggingStreamInterceptor(logger),
+		metrics.StreamServerInterceptor(),
+		rateLimiter.StreamServerInterceptor(),
+	}
+
+	var tracerProvider *sdktrace.TracerProvider
+	if tracing, _ := strconv.ParseBool(config.Tracing); tracing {
+		tracerProvider, err = NewTracerProvider(context.Background(), config.TraceFile)
+		if err != nil {
+			logger.Fatalf("cannot init tracing: %v", err)
+		}
+		mongoOptions.SetMonitor(NewTracingCommandMonitor())
+		interceptors = append([]grpc.UnaryServerInterceptor{TracingInterceptor()}, interceptors...)
+		streamInterceptors = append([]grpc.StreamServerInterceptor{TracingStreamInterceptor()}, streamInterceptors...)
 	}
 
 	logger.Infof("connecting to %v", config.MongoDSN)
-	mongoClient, err := mongo.NewClient(options.Client().ApplyURI(config.MongoDSN))
+	mongoClient, err := mongo.NewClient(mongoOptions)
 	if err != nil {
 		logger.Panic("cannot instantiate mongo client")
 	}
@@ -46,10 +124,60 @@ func main() {
 		logger.Panicf("cannot connect client: %v", err)
 	}
 
-	storage := NewStorage(mongoClient, config.MongoDBName)
-	if initDb, _ := strconv.ParseBool(config.MongoInitDB); initDb {
-		if err := storage.Init(); err != nil {
-			logger.Panicf("cannot init db: %v", err)
+	migrator := NewMigrator(logger, mongoClient.Database(config.MongoDBName), migrations)
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrateCommand(migrator, os.Args[2:]); err != nil {
+			logger.Fatal(err)
+		}
+		return
+	}
+	if migrate, _ := strconv.ParseBool(config.MongoMigrate); migrate {
+		if err := migrator.Up(context.Background()); err != nil {
+			logger.Fatalf("cannot migrate db: %v", err)
+		}
+	}
+
+	storage := NewStorage(mongoClient, config.MongoDBName, logger, metrics)
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		if err := runSeedCommand(storage, config.MongoDBName, os.Args[2:]); err != nil {
+			logger.Fatal(err)
+		}
+		return
+	}
+	if _, ok := os.LookupEnv("MONGO_INIT_DB"); ok {
+		logger.Warn("MONGO_INIT_DB is no longer supported and is ignored, use MONGO_FIXTURES or the seed command")
+	}
+	if config.MongoFixtures != "" {
+		fixtures, err := ReadFixtures(config.MongoFixtures)
+		if err != nil {
+			logger.Fatal(err)
+		}
+		if err := storage.LoadFixtures(context.Background(), fixtures); err != nil {
+			logger.Panicf("cannot load fixtures: %v", err)
+		}
+	}
+
+	prices, err := newPriceSource(config)
+	if err != nil {
+		logger.Fatalf("cannot init price source: %v", err)
+	}
+
+	var telegramClient *TelegramClient
+	if config.TelegramToken != "" {
+		telegramClient = NewTelegramClient(config.TelegramUrl, config.TelegramToken)
+	}
+
+	sinks, err := newNotificationSinks(config, telegramClient)
+	if err != nil {
+		logger.Fatalf("cannot init notifications: %v", err)
+	}
+	notifier := NewNotifier(logger, storage, sinks)
+	interceptors = append(interceptors, notifier.ErrorInterceptor(), IdempotencyInterceptor(logger, storage, config.IdempotentTTL, config.IdempotLease))
+
+	portfolioMaxDrawdown := decimal.Zero
+	if config.MaxDrawdown != "" {
+		if portfolioMaxDrawdown, err = decimal.NewFromString(config.MaxDrawdown); err != nil {
+			logger.Fatalf("can't parse PORTFOLIO_MAX_DRAWDOWN env: %v", err)
 		}
 	}
 
@@ -58,23 +186,160 @@ func main() {
 		parseInts(logger, "USER_OPERATORS_LIST env", config.UserOperators),
 		parseInts(logger, "USER_VIEWERS_LIST env", config.UserViewers),
 		storage,
+		prices,
+		NewPaperTrader(storage, prices),
+		notifier,
 	)
 
-	grpcServer := grpc.NewServer(grpc.ConnectionTimeout(5 * time.Second))
+	approvalMethods, err := parseApprovalMethods(config.ApprovalRpcs)
+	if err != nil {
+		logger.Fatalf("can't parse APPROVAL_RPCS env: %v", err)
+	}
+	interceptors = append(interceptors, server.ApprovalInterceptor(ApprovalPolicy{approvalMethods, config.ApprovalTTL}))
+
+	grpcServer := grpc.NewServer(
+		grpc.ConnectionTimeout(5*time.Second),
+		grpc.ChainUnaryInterceptor(interceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
 	gandalfPb.RegisterGandalfServer(grpcServer, server)
 
+	healthChecker := NewHealthChecker(logger, storage, config.HealthCheck)
+	healthPb.RegisterHealthServer(grpcServer, healthChecker.Server())
+	if enabled, _ := strconv.ParseBool(config.Reflection); enabled {
+		reflection.Register(grpcServer)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go healthChecker.Run(ctx)
+	go notifier.Run(ctx)
+	go NewCircuitBreaker(logger, storage, prices, server, config.Drawdown, config.DrawdownChk, portfolioMaxDrawdown).Run(ctx)
+	go NewScheduler(logger, storage, server, config.SchedulerTick).Run(ctx)
+	go NewExcursionTracker(logger, storage, prices, config.ExcursionTick).Run(ctx)
+	go NewBalanceSnapshotter(logger, storage, prices, strings.ToLower(config.SnapshotQuote), config.SnapshotTick).Run(ctx)
+	if err := server.ReconcileApprovals(ctx); err != nil {
+		logger.Errorf("cannot reconcile approvals: %v", err)
+	}
+
+	if telegramClient != nil {
+		bot := NewTelegramBot(logger, telegramClient, server, interceptors)
+		go bot.Run(ctx)
+	}
+
 	listener, err := net.Listen("tcp", config.Addr)
 	if err != nil {
 		logger.Fatalf("failed to listen: %v", err)
 	}
 
+	var httpServers []*http.Server
+	if config.HttpAddr != "" {
+		gateway, err := NewGateway(logger, server, interceptors, streamInterceptors, config.HttpTokens)
+		if err != nil {
+			logger.Fatalf("cannot init gateway: %v", err)
+		}
+		httpServers = append(httpServers, serveHttp(logger, "gateway", config.HttpAddr, gateway))
+	}
+	if config.MetricsAddr != "" {
+		metrics.RegisterBusinessCollector(logger, storage)
+
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+		httpServers = append(httpServers, serveHttp(logger, "metrics", config.MetricsAddr, mux))
+	}
+
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+		sig := <-signals
+
+		logger.Infof("received %v, shutting down", sig)
+		cancel()
+		healthChecker.Shutdown()
+		gracefulStop(logger, grpcServer, config.StopTimeout)
+	}()
+
 	logger.Info("gandalf started")
 	if err := grpcServer.Serve(listener); err != nil {
 		logger.Fatalf("gandalf stopped with error: %v", err)
 	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), config.StopTimeout)
+	defer shutdownCancel()
+	for _, httpServer := range httpServers {
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			logger.Errorf("cannot stop http server %s: %v", httpServer.Addr, err)
+		}
+	}
+	if err := storage.Close(shutdownCtx); err != nil {
+		logger.Errorf("cannot close storage: %v", err)
+	}
+	if tracerProvider != nil {
+		if err := tracerProvider.Shutdown(shutdownCtx); err != nil {
+			logger.Errorf("cannot flush traces: %v", err)
+		}
+	}
 	logger.Info("gandalf stopped")
 }
 
+func serveHttp(logger *zap.SugaredLogger, name, addr string, handler http.Handler) *http.Server {
+	httpServer := &http.Server{Addr: addr, Handler: handler}
+	go func() {
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Errorf("%s server stopped with error: %v", name, err)
+		}
+	}()
+	return httpServer
+}
+
+// gracefulStop waits for in-flight requests to finish and cancels them once
+// the timeout is reached.
+func gracefulStop(logger *zap.SugaredLogger, grpcServer *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		logger.Warnf("requests didn't finish in %v, stopping", timeout)
+		grpcServer.Stop()
+	}
+}
+
+func newPriceSource(config appConfig) (PriceSource, error) {
+	switch config.PriceSource {
+	case "huobi":
+		return NewHuobiPriceSource(config.HuobiApiUrl), nil
+	case "replay":
+		return NewReplayPriceSourceFromFile(config.PriceReplay)
+	default:
+		return nil, errors.New(fmt.Sprintf("unknown price source '%s'", config.PriceSource))
+	}
+}
+
+// newNotificationSinks returns the sinks subscribers may use, a sink is only
+// available when it is configured.
+func newNotificationSinks(config appConfig, telegramClient *TelegramClient) (map[string]Sink, error) {
+	sinks := make(map[string]Sink)
+	if config.WebhookSecret != "" {
+		sinks["webhook"] = NewWebhookSink(config.WebhookSecret, strings.Split(config.WebhookHosts, ","))
+	}
+	if telegramClient != nil {
+		sinks["telegram"] = NewTelegramSink(telegramClient)
+	}
+	if config.NotifyLog != "" {
+		sink, err := NewLogFileSink(config.NotifyLog)
+		if err != nil {
+			return nil, err
+		}
+		sinks["log"] = sink
+	}
+	return sinks, nil
+}
+
 // TODO move it to hermes-utils
 func parseInts(logger *zap.SugaredLogger, srcName, src string) []int64 {
 	ss := strings.Split(src, ",")
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+type Metrics struct {
+	registry        *prometheus.Registry
+	rpcRequests     *prometheus.CounterVec
+	rpcDuration     *prometheus.HistogramVec
+	storageDuration *prometheus.HistogramVec
+	rateLimited     *prometheus.CounterVec
+}
+
+const metricsNamespace = AppName
+
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		rpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "grpc_requests_total",
+			Help:      "Number of handled gRPC requests by method and status code.",
+		}, []string{"method", "code"}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "grpc_request_duration_seconds",
+			Help:      "Latency of gRPC requests by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		storageDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "storage_operation_duration_seconds",
+			Help:      "Latency of Mongo operations by storage method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "rate_limited_total",
+			Help:      "Number of requests rejected by the rate limiter by method and method class.",
+		}, []string{"method", "class"}),
+	}
+
+	m.registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		m.rpcRequests,
+		m.rpcDuration,
+		m.storageDuration,
+		m.rateLimited,
+	)
+
+	return m
+}
+
+// UnaryServerInterceptor counts requests and observes their latency.
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		m.rpcDuration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		m.rpcRequests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor counts streaming calls and observes their duration.
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+
+		m.rpcDuration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		m.rpcRequests.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+
+		return err
+	}
+}
+
+// observeStorage records the latency of a storage operation, use it as
+// defer m.observeStorage("Operation", time.Now()).
+func (m *Metrics) observeStorage(operation string, start time.Time) {
+	if m == nil {
+		return
+	}
+
+	m.storageDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
+
+// observeRateLimited counts a request rejected by the rate limiter.
+func (m *Metrics) observeRateLimited(method string, class string) {
+	if m == nil {
+		return
+	}
+
+	m.rateLimited.WithLabelValues(method, class).Inc()
+}
+
+// RegisterBusinessCollector exposes gauges computed from the stored symbols and deals.
+func (m *Metrics) RegisterBusinessCollector(logger *zap.SugaredLogger, storage *Storage) {
+	m.registry.MustRegister(newBusinessCollector(logger, storage))
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// businessCollector reads symbols and deals on every scrape, so the gauges
+// always reflect the database regardless of which instance changed it.
+type businessCollector struct {
+	logger  *zap.SugaredLogger
+	storage *Storage
+
+	symbols          *prometheus.Desc
+	openDeals        *prometheus.Desc
+	exposure         *prometheus.Desc
+	limitUtilization *prometheus.Desc
+}
+
+const businessCollectTimeout = 5 * time.Second
+
+func newBusinessCollector(logger *zap.SugaredLogger, storage *Storage) *businessCollector {
+	return &businessCollector{
+		logger:  logger,
+		storage: storage,
+		symbols: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "symbols"),
+			"Number of trading symbols by status.",
+			[]string{"status"}, nil,
+		),
+		openDeals: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "open_deals"),
+			"Number of open deals by symbol.",
+			[]string{"symbol"}, nil,
+		),
+		exposure: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "exposure"),
+			"Sum of the quote currency amounts of open deals by symbol.",
+			[]string{"symbol"}, nil,
+		),
+		limitUtilization: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "limit_utilization_ratio"),
+			"Exposure of a symbol divided by its limit.",
+			[]string{"symbol"}, nil,
+		),
+	}
+}
+
+func (c *businessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.symbols
+	ch <- c.openDeals
+	ch <- c.exposure
+	ch <- c.limitUtilization
+}
+
+func (c *businessCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), businessCollectTimeout)
+	defer cancel()
+
+	tradingSymbols, err := c.storage.GetTradingSymbols(ctx)
+	if err != nil {
+		c.logger.Errorf("cannot collect symbol metrics: %v", err)
+		return
+	}
+
+	counts := make(map[string]int)
+	exposures := make(map[string]decimal.Decimal)
+	err = c.storage.EachDeal(ctx, nil, time.Time{}, time.Time{}, func(deal *Deal) error {
+		counts[deal.Symbol]++
+		exposures[deal.Symbol] = exposures[deal.Symbol].Add(deal.AmountCurrency)
+		return nil
+	})
+	if err != nil {
+		c.logger.Errorf("cannot collect deal metrics: %v", err)
+		return
+	}
+
+	statuses := make(map[pb.TradingSymbol_TradingStatus]int)
+	for status := range pb.TradingSymbol_TradingStatus_name {
+		statuses[pb.TradingSymbol_TradingStatus(status)] = 0
+	}
+	for _, symbol := range tradingSymbols {
+		statuses[symbol.Status]++
+	}
+	for status, n := range statuses {
+		ch <- prometheus.MustNewConstMetric(c.symbols, prometheus.GaugeValue, float64(n), status.String())
+	}
+
+	for _, symbol := range tradingSymbols {
+		exposure := exposures[symbol.Symbol]
+		ch <- prometheus.MustNewConstMetric(c.openDeals, prometheus.GaugeValue, float64(counts[symbol.Symbol]), symbol.Symbol)
+		ch <- prometheus.MustNewConstMetric(c.exposure, prometheus.GaugeValue, exposure.InexactFloat64(), symbol.Symbol)
+		if symbol.Limit.IsPositive() {
+			utilization := exposure.Div(symbol.Limit).InexactFloat64()
+			ch <- prometheus.MustNewConstMetric(c.limitUtilization, prometheus.GaugeValue, utilization, symbol.Symbol)
+		}
+	}
+}
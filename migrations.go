@@ -0,0 +1,441 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// Migration is a versioned change of the database schema. Up and Down must be
+// idempotent, a migration interrupted halfway is simply run again.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+	Down    func(ctx context.Context, db *mongo.Database) error
+}
+
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type appliedMigration struct {
+	Version   int       `bson:"_id"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+type migrationLock struct {
+	Id        string    `bson:"_id"`
+	Owner     string    `bson:"owner"`
+	ExpiresAt time.Time `bson:"expires_at"`
+}
+
+type Migrator struct {
+	logger     *zap.SugaredLogger
+	db         *mongo.Database
+	migrations []Migration
+	owner      string
+	lockTTL    time.Duration
+}
+
+const (
+	migrationsCollection     = "migrations"
+	migrationLocksCollection = "migration_locks"
+	migrationLockId          = "migrate"
+)
+
+var (
+	errMigrationLocked = errors.New("migrations are locked by another instance")
+)
+
+// migrations are applied in the order of their versions, append new ones to the end.
+var migrations = []Migration{
+	{
+		Version: 1,
+		Name:    "symbols_decimal128",
+		Up:      convertFields(symbolsCollection, "$toDecimal", "balance", "limit"),
+		Down:    convertFields(symbolsCollection, "$toDouble", "balance", "limit"),
+	},
+	{
+		Version: 2,
+		Name:    "deals_decimal128",
+		Up:      convertFields(dealsCollection, "$toDecimal", "amount", "amount_currency", "delta_amount", "delta_percent", "open_price"),
+		Down:    convertFields(dealsCollection, "$toDouble", "amount", "amount_currency", "delta_amount", "delta_percent", "open_price"),
+	},
+	{
+		Version: 3,
+		Name:    "deals_symbol_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection(dealsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "symbol", Value: 1}, {Key: "created_at", Value: 1}},
+				Options: options.Index().SetName("symbol_created_at"),
+			})
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndex(ctx, db.Collection(dealsCollection), "symbol_created_at")
+		},
+	},
+	{
+		Version: 4,
+		Name:    "closed_deals_closed_at_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection(closedDealsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "closed_at", Value: 1}},
+				Options: options.Index().SetName("closed_at"),
+			})
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndex(ctx, db.Collection(closedDealsCollection), "closed_at")
+		},
+	},
+	{
+		Version: 5,
+		Name:    "schedules_next_run_at_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection(schedulesCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "next_run_at", Value: 1}},
+				Options: options.Index().SetName("next_run_at"),
+			})
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndex(ctx, db.Collection(schedulesCollection), "next_run_at")
+		},
+	},
+	{
+		Version: 6,
+		Name:    "symbols_default_config",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection(symbolConfigsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "symbol", Value: 1}, {Key: "version", Value: -1}},
+				Options: options.Index().SetName("symbol_version"),
+			})
+			if err != nil {
+				return err
+			}
+
+			cursor, err := db.Collection(symbolsCollection).Find(ctx, bson.M{"config": bson.M{"$exists": false}})
+			if err != nil {
+				return err
+			}
+			var symbols []*TradingSymbol
+			if err := cursor.All(ctx, &symbols); err != nil {
+				return err
+			}
+
+			config := newSymbolConfigVersion(defaultSymbolConfig(), 1, 0)
+			for _, symbol := range symbols {
+				_, err := db.Collection(symbolsCollection).UpdateOne(ctx, bson.M{"_id": symbol.Symbol}, bson.M{"$set": bson.M{"config": config}})
+				if err != nil {
+					return err
+				}
+				_, err = db.Collection(symbolConfigsCollection).InsertOne(ctx, &symbolConfigVersion{
+					Id:           fmt.Sprintf("%s:%d", symbol.Symbol, config.Version),
+					Symbol:       symbol.Symbol,
+					SymbolConfig: config,
+				})
+				if err != nil && !mongo.IsDuplicateKeyError(err) {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection(symbolsCollection).UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"config": ""}})
+			if err != nil {
+				return err
+			}
+			return db.Collection(symbolConfigsCollection).Drop(ctx)
+		},
+	},
+	{
+		Version: 7,
+		Name:    "symbols_deals_version",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			for _, collection := range []string{symbolsCollection, dealsCollection} {
+				_, err := db.Collection(collection).UpdateMany(
+					ctx,
+					bson.M{"version": bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{"version": int64(1)}},
+				)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			for _, collection := range []string{symbolsCollection, dealsCollection} {
+				_, err := db.Collection(collection).UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"version": ""}})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version: 8,
+		Name:    "idempotency_keys_ttl_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection(idempotencyCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "expires_at", Value: 1}},
+				Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+			})
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndex(ctx, db.Collection(idempotencyCollection), "expires_at_ttl")
+		},
+	},
+	{
+		Version: 9,
+		Name:    "deals_sort_indexes",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			var indexes []mongo.IndexModel
+			for _, field := range dealSortFields {
+				indexes = append(indexes, mongo.IndexModel{
+					Keys:    bson.D{{Key: field, Value: 1}, {Key: "_id", Value: 1}},
+					Options: options.Index().SetName(field + "_id"),
+				})
+			}
+			_, err := db.Collection(dealsCollection).Indexes().CreateMany(ctx, indexes)
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			for _, field := range dealSortFields {
+				if err := dropIndex(ctx, db.Collection(dealsCollection), field+"_id"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version: 10,
+		Name:    "balance_history_start_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection(balancesCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "start", Value: 1}},
+				Options: options.Index().SetName("start"),
+			})
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndex(ctx, db.Collection(balancesCollection), "start")
+		},
+	},
+	{
+		Version: 11,
+		Name:    "approvals_status_index",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection(approvalsCollection).Indexes().CreateOne(ctx, mongo.IndexModel{
+				Keys:    bson.D{{Key: "status", Value: 1}, {Key: "expires_at", Value: 1}},
+				Options: options.Index().SetName("status_expires_at"),
+			})
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			return dropIndex(ctx, db.Collection(approvalsCollection), "status_expires_at")
+		},
+	},
+}
+
+func NewMigrator(
+	logger *zap.SugaredLogger,
+	db *mongo.Database,
+	migrations []Migration,
+) *Migrator {
+	hostname, _ := os.Hostname()
+	return &Migrator{
+		logger:     logger,
+		db:         db,
+		migrations: migrations,
+		owner:      fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		lockTTL:    10 * time.Minute,
+	}
+}
+
+// Up applies all pending migrations.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.withLock(ctx, func() error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			m.logger.Infof("applying migration %d %s", migration.Version, migration.Name)
+			if err := migration.Up(ctx, m.db); err != nil {
+				return errors.New(fmt.Sprintf("migration %d %s failed: %v", migration.Version, migration.Name, err))
+			}
+
+			_, err := m.getMigrationsCollection().InsertOne(ctx, &appliedMigration{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Down reverts the last applied migration.
+func (m *Migrator) Down(ctx context.Context) error {
+	return m.withLock(ctx, func() error {
+		applied, err := m.applied(ctx)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+
+			m.logger.Infof("reverting migration %d %s", migration.Version, migration.Name)
+			if err := migration.Down(ctx, m.db); err != nil {
+				return errors.New(fmt.Sprintf("migration %d %s failed: %v", migration.Version, migration.Name, err))
+			}
+
+			_, err := m.getMigrationsCollection().DeleteOne(ctx, bson.M{"_id": migration.Version})
+			return err
+		}
+
+		m.logger.Info("no migrations to revert")
+		return nil
+	})
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+		}
+		if a, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = a.AppliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int]*appliedMigration, error) {
+	cursor, err := m.getMigrationsCollection().Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	var list []*appliedMigration
+	if err := cursor.All(ctx, &list); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]*appliedMigration, len(list))
+	for _, a := range list {
+		applied[a.Version] = a
+	}
+
+	return applied, nil
+}
+
+// withLock runs fn while holding the migration lock, so that two instances
+// starting at the same time don't migrate concurrently. A lock left behind by
+// a crashed instance is taken over once it expires.
+func (m *Migrator) withLock(ctx context.Context, fn func() error) error {
+	now := time.Now()
+	lock := &migrationLock{migrationLockId, m.owner, now.Add(m.lockTTL)}
+
+	_, err := m.getLocksCollection().InsertOne(ctx, lock)
+	if mongo.IsDuplicateKeyError(err) {
+		res := m.getLocksCollection().FindOneAndReplace(
+			ctx,
+			bson.M{"_id": migrationLockId, "expires_at": bson.M{"$lt": now}},
+			lock,
+		)
+		if res.Err() == mongo.ErrNoDocuments {
+			return errMigrationLocked
+		} else if res.Err() != nil {
+			return res.Err()
+		}
+	} else if err != nil {
+		return err
+	}
+
+	defer func() {
+		_, err := m.getLocksCollection().DeleteOne(
+			context.Background(),
+			bson.M{"_id": migrationLockId, "owner": m.owner},
+		)
+		if err != nil {
+			m.logger.Errorf("cannot release migration lock: %v", err)
+		}
+	}()
+
+	return fn()
+}
+
+func (m *Migrator) getMigrationsCollection() *mongo.Collection {
+	return m.db.Collection(migrationsCollection)
+}
+
+func (m *Migrator) getLocksCollection() *mongo.Collection {
+	return m.db.Collection(migrationLocksCollection)
+}
+
+// convertFields returns a migration step converting numeric fields of all
+// documents in the collection with the given aggregation operator.
+func convertFields(collection, operator string, fields ...string) func(ctx context.Context, db *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		set := bson.M{}
+		for _, field := range fields {
+			set[field] = bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{bson.M{"$type": "$" + field}, "missing"}},
+				"$$REMOVE",
+				bson.M{operator: "$" + field},
+			}}
+		}
+
+		_, err := db.Collection(collection).UpdateMany(ctx, bson.M{}, mongo.Pipeline{{{Key: "$set", Value: set}}})
+		return err
+	}
+}
+
+func dropIndex(ctx context.Context, collection *mongo.Collection, name string) error {
+	_, err := collection.Indexes().DropOne(ctx, name)
+	if cmdErr, ok := err.(mongo.CommandError); ok && cmdErr.Name == "IndexNotFound" {
+		return nil
+	}
+	return err
+}
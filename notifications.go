@@ -0,0 +1,371 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type EventType string
+
+const (
+	EventDealOpened    EventType = "deal_opened"
+	EventDealClosed    EventType = "deal_closed"
+	EventStatusChanged EventType = "status_changed"
+	EventLimitBreached EventType = "limit_breached"
+	EventApproval      EventType = "approval"
+	EventError         EventType = "error"
+)
+
+var eventTypes = []EventType{EventDealOpened, EventDealClosed, EventStatusChanged, EventLimitBreached, EventApproval, EventError}
+
+type Event struct {
+	Type    EventType         `json:"type"`
+	Time    time.Time         `json:"time"`
+	Symbol  string            `json:"symbol,omitempty"`
+	Message string            `json:"message"`
+	Data    map[string]string `json:"data,omitempty"`
+}
+
+// Sink delivers events to a target chosen by the subscriber.
+type Sink interface {
+	Send(ctx context.Context, target string, event Event) error
+}
+
+// TargetValidator is implemented by sinks that restrict the targets a user may
+// subscribe with, operators may be allowed more than viewers.
+type TargetValidator interface {
+	ValidateTarget(userId int64, operator bool, target string) error
+}
+
+// Notifier fans published events out to the sinks of the matching
+// subscriptions. Publishing never blocks the caller, events are dropped when
+// the queue is full.
+type Notifier struct {
+	logger  *zap.SugaredLogger
+	storage *Storage
+	sinks   map[string]Sink
+	queue   chan Event
+}
+
+const (
+	notifierQueueSize   = 1000
+	notifierSendTimeout = 10 * time.Second
+)
+
+func NewNotifier(
+	logger *zap.SugaredLogger,
+	storage *Storage,
+	sinks map[string]Sink,
+) *Notifier {
+	return &Notifier{
+		logger:  logger,
+		storage: storage,
+		sinks:   sinks,
+		queue:   make(chan Event, notifierQueueSize),
+	}
+}
+
+func (n *Notifier) Publish(event Event) {
+	if n == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	select {
+	case n.queue <- event:
+	default:
+		n.logger.Warnf("notification queue is full, dropping %s event", event.Type)
+	}
+}
+
+// Run delivers events until ctx is done.
+func (n *Notifier) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-n.queue:
+			n.deliver(ctx, event)
+		}
+	}
+}
+
+// ValidateSubscription checks that the subscription only refers to known
+// events, configured sinks and targets the user may send events to.
+func (n *Notifier) ValidateSubscription(subscription *Subscription, operator bool) error {
+	for _, event := range subscription.Events {
+		if !eventTypeKnown(event) {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("unknown event '%s'", event))
+		}
+	}
+	for _, channel := range subscription.Channels {
+		sink, ok := n.sinks[channel.Sink]
+		if !ok {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("sink '%s' is not configured", channel.Sink))
+		}
+		if validator, ok := sink.(TargetValidator); ok {
+			if err := validator.ValidateTarget(subscription.UserId, operator, channel.Target); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (n *Notifier) deliver(ctx context.Context, event Event) {
+	subscriptions, err := n.storage.GetSubscriptions(ctx)
+	if err != nil {
+		n.logger.Errorf("cannot load subscriptions: %v", err)
+		return
+	}
+
+	// several users may share a channel, e.g. a group chat, it gets the event once
+	delivered := make(map[SubscriptionChannel]bool)
+	for _, subscription := range subscriptions {
+		if !subscription.Matches(event) {
+			continue
+		}
+
+		for _, channel := range subscription.Channels {
+			sink, ok := n.sinks[channel.Sink]
+			if !ok || delivered[channel] {
+				continue
+			}
+			delivered[channel] = true
+
+			sendCtx, cancel := context.WithTimeout(ctx, notifierSendTimeout)
+			if err := sink.Send(sendCtx, channel.Target, event); err != nil {
+				n.logger.Errorf("cannot send %s event to %s of user %d: %v", event.Type, channel.Sink, subscription.UserId, err)
+			}
+			cancel()
+		}
+	}
+}
+
+// ErrorInterceptor publishes an error event for calls failed on the server side.
+func (n *Notifier) ErrorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+
+		switch status.Code(err) {
+		case codes.Unknown, codes.Internal, codes.DataLoss:
+			n.Publish(Event{
+				Type:    EventError,
+				Message: fmt.Sprintf("%s failed: %v", info.FullMethod, err),
+			})
+		}
+
+		return resp, err
+	}
+}
+
+func eventTypeKnown(event EventType) bool {
+	for _, t := range eventTypes {
+		if t == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookSink posts events as JSON. The body is signed with HMAC-SHA256 of the
+// shared secret, the hex encoded signature is sent in X-Gandalf-Signature.
+// Targets must be https URLs of the allowed hosts, without allowed hosts only
+// operators may set webhook targets.
+type WebhookSink struct {
+	secret []byte
+	hosts  map[string]bool
+	client *http.Client
+}
+
+const webhookSignatureHeader = "X-Gandalf-Signature"
+
+func NewWebhookSink(secret string, hosts []string) *WebhookSink {
+	allowed := make(map[string]bool)
+	for _, host := range hosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			allowed[host] = true
+		}
+	}
+
+	return &WebhookSink{
+		secret: []byte(secret),
+		hosts:  allowed,
+		client: &http.Client{
+			Timeout: notifierSendTimeout,
+			// a redirect could lead anywhere, past the allowed hosts
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *WebhookSink) ValidateTarget(_ int64, operator bool, target string) error {
+	if len(s.hosts) == 0 && !operator {
+		return status.Error(codes.PermissionDenied, "only operators may set webhook targets")
+	}
+	if err := s.checkTarget(target); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+func (s *WebhookSink) checkTarget(target string) error {
+	u, err := url.Parse(target)
+	if err != nil || u.Host == "" {
+		return errors.New(fmt.Sprintf("invalid webhook url '%s'", target))
+	}
+	if u.Scheme != "https" {
+		return errors.New(fmt.Sprintf("webhook url '%s' must use https", target))
+	}
+	if u.User != nil {
+		return errors.New(fmt.Sprintf("webhook url '%s' must not contain credentials", target))
+	}
+	if len(s.hosts) > 0 && !s.hosts[strings.ToLower(u.Hostname())] {
+		return errors.New(fmt.Sprintf("webhook host '%s' is not allowed", u.Hostname()))
+	}
+	return nil
+}
+
+func (s *WebhookSink) Send(ctx context.Context, target string, event Event) error {
+	// subscriptions stored before the hosts were restricted are checked again
+	if err := s.checkTarget(target); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return errors.New(fmt.Sprintf("webhook responded with %s", resp.Status))
+	}
+	return nil
+}
+
+// TelegramSink sends events as messages to the chat given as the target.
+type TelegramSink struct {
+	client *TelegramClient
+}
+
+func NewTelegramSink(client *TelegramClient) *TelegramSink {
+	return &TelegramSink{
+		client: client,
+	}
+}
+
+// ValidateTarget lets viewers subscribe their own chat only, operators may
+// subscribe any chat, e.g. a group.
+func (s *TelegramSink) ValidateTarget(userId int64, operator bool, target string) error {
+	chatId, err := strconv.ParseInt(target, 10, 64)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid telegram chat id '%s'", target))
+	}
+	if !operator && chatId != userId {
+		return status.Error(codes.PermissionDenied, "viewers may only subscribe their own telegram chat")
+	}
+	return nil
+}
+
+func (s *TelegramSink) Send(ctx context.Context, target string, event Event) error {
+	chatId, err := strconv.ParseInt(target, 10, 64)
+	if err != nil {
+		return errors.New(fmt.Sprintf("invalid telegram chat id '%s'", target))
+	}
+
+	text := fmt.Sprintf("[%s] %s", strings.ReplaceAll(string(event.Type), "_", " "), event.Message)
+	return s.client.SendMessage(ctx, chatId, text, nil)
+}
+
+// LogFileSink appends events to a file as JSON lines, the target is ignored.
+type LogFileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewLogFileSink(path string) (*LogFileSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LogFileSink{
+		file: file,
+	}, nil
+}
+
+func (s *LogFileSink) Send(_ context.Context, _ string, event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.file.Write(append(line, '\n'))
+	return err
+}
+
+// Matches tells whether the event is one the subscriber wants, empty event and
+// symbol lists match everything.
+func (s *Subscription) Matches(event Event) bool {
+	if len(s.Events) > 0 {
+		found := false
+		for _, t := range s.Events {
+			found = found || t == event.Type
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(s.Symbols) > 0 && event.Symbol != "" {
+		for _, symbol := range s.Symbols {
+			if symbol == event.Symbol {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// OpenApiSpec builds an OpenAPI 3 document of the gateway routes from the
+// descriptors compiled from service.proto, so the spec follows the proto.
+func OpenApiSpec(routes []gatewayRoute) map[string]interface{} {
+	service := pb.File_pb_service_proto.Services().ByName("Gandalf")
+	schemas := make(map[string]interface{})
+	paths := make(map[string]map[string]interface{})
+
+	for _, route := range routes {
+		method := service.Methods().ByName(protoreflect.Name(route.Rpc))
+		input, output := method.Input(), method.Output()
+
+		var parameters []interface{}
+
+		if !isReadOnlyRpc(route.Rpc) {
+			parameters = append(parameters, map[string]interface{}{
+				"name":     "Idempotency-Key",
+				"in":       "header",
+				"required": false,
+				"schema":   map[string]interface{}{"type": "string"},
+			})
+		}
+
+		pathParams := make(map[string]bool)
+		for _, part := range strings.Split(route.Path, "/") {
+			if strings.HasPrefix(part, "{") {
+				name := strings.Trim(part, "{}")
+				pathParams[name] = true
+				parameters = append(parameters, map[string]interface{}{
+					"name":     name,
+					"in":       "path",
+					"required": true,
+					"schema":   fieldSchema(input.Fields().ByJSONName(name), schemas),
+				})
+			}
+		}
+
+		ok := jsonContent("OK", schemaRef(output, schemas))
+		if method.IsStreamingServer() {
+			// the gateway writes the chunks of streams as a file
+			ok = map[string]interface{}{
+				"description": "File",
+				"content": map[string]interface{}{
+					"application/octet-stream": map[string]interface{}{
+						"schema": map[string]interface{}{"type": "string", "format": "binary"},
+					},
+				},
+			}
+		}
+
+		operation := map[string]interface{}{
+			"operationId": route.Rpc,
+			"responses": map[string]interface{}{
+				"200": ok,
+				"default": jsonContent("Error", map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"code":    map[string]interface{}{"type": "string"},
+						"message": map[string]interface{}{"type": "string"},
+					},
+				}),
+			},
+		}
+
+		if route.Method == http.MethodGet {
+			fields := input.Fields()
+			for i := 0; i < fields.Len(); i++ {
+				fd := fields.Get(i)
+				// the user id comes from the bearer token
+				if fd.JSONName() == "userId" || pathParams[fd.JSONName()] || fd.Kind() == protoreflect.MessageKind && fd.Message().FullName() != "google.protobuf.Timestamp" {
+					continue
+				}
+				parameters = append(parameters, map[string]interface{}{
+					"name":   fd.JSONName(),
+					"in":     "query",
+					"schema": fieldSchema(fd, schemas),
+				})
+			}
+		} else {
+			operation["requestBody"] = map[string]interface{}{
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": schemaRef(input, schemas)},
+				},
+			}
+		}
+		operation["parameters"] = parameters
+
+		if paths[route.Path] == nil {
+			paths[route.Path] = make(map[string]interface{})
+		}
+		paths[route.Path][strings.ToLower(route.Method)] = operation
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "Gandalf",
+			"version": "v1",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"securitySchemes": map[string]interface{}{
+				"bearer": map[string]interface{}{"type": "http", "scheme": "bearer"},
+			},
+		},
+		"security": []interface{}{map[string]interface{}{"bearer": []string{}}},
+	}
+}
+
+func jsonContent(description string, schema interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schema},
+		},
+	}
+}
+
+// schemaRef adds the message schema to the components and returns a reference to it.
+func schemaRef(md protoreflect.MessageDescriptor, schemas map[string]interface{}) map[string]interface{} {
+	name := strings.TrimPrefix(string(md.FullName()), "gandalf.")
+	ref := map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	if _, ok := schemas[name]; ok {
+		return ref
+	}
+
+	properties := make(map[string]interface{})
+	schemas[name] = map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		properties[fd.JSONName()] = fieldSchema(fd, schemas)
+	}
+
+	return ref
+}
+
+func fieldSchema(fd protoreflect.FieldDescriptor, schemas map[string]interface{}) map[string]interface{} {
+	var schema map[string]interface{}
+
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		schema = map[string]interface{}{"type": "string"}
+	case protoreflect.BoolKind:
+		schema = map[string]interface{}{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		schema = map[string]interface{}{"type": "integer", "format": "int32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// protojson encodes 64-bit integers as strings
+		schema = map[string]interface{}{"type": "string", "format": "int64"}
+	case protoreflect.FloatKind:
+		schema = map[string]interface{}{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		schema = map[string]interface{}{"type": "number", "format": "double"}
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		names := make([]string, 0, values.Len())
+		for i := 0; i < values.Len(); i++ {
+			names = append(names, string(values.Get(i).Name()))
+		}
+		schema = map[string]interface{}{"type": "string", "enum": names}
+	case protoreflect.MessageKind:
+		if fd.Message().FullName() == "google.protobuf.Timestamp" {
+			schema = map[string]interface{}{"type": "string", "format": "date-time"}
+		} else {
+			schema = schemaRef(fd.Message(), schemas)
+		}
+	default:
+		schema = map[string]interface{}{}
+	}
+
+	if fd.IsList() {
+		schema = map[string]interface{}{"type": "array", "items": schema}
+	}
+	if options, ok := fd.Options().(*descriptorpb.FieldOptions); ok && options.GetDeprecated() {
+		schema = map[string]interface{}{"allOf": []interface{}{schema}, "deprecated": true}
+	}
+
+	return schema
+}
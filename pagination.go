@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+var errInvalidPageToken = status.Error(codes.InvalidArgument, "invalid page token")
+
+// dealSortFields maps the sort options of GetActiveDeals to the bson fields,
+// each of them has an index together with _id, see migration 9.
+var dealSortFields = map[pb.DealsRequest_SortField]string{
+	pb.DealsRequest_CREATED_AT:    "created_at",
+	pb.DealsRequest_DELTA_PERCENT: "delta_percent",
+	pb.DealsRequest_AMOUNT:        "amount",
+}
+
+// pageCursor is the position after the last item of a page. Pages are sorted
+// by the field and then by _id, so the cursor is stable for equal values.
+type pageCursor struct {
+	Sort       string `json:"sort"`
+	Descending bool   `json:"desc,omitempty"`
+	Value      string `json:"value,omitempty"`
+	Id         string `json:"id"`
+}
+
+func encodePageToken(cursor *pageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken returns nil for an empty token. The token must have been
+// issued for the same sort, a cursor of another order would skip items.
+func decodePageToken(token string, sort string, descending bool) (*pageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidPageToken
+	}
+	cursor := &pageCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil || cursor.Id == "" {
+		return nil, errInvalidPageToken
+	}
+	if cursor.Sort != sort || cursor.Descending != descending {
+		return nil, status.Error(codes.InvalidArgument, "page token was issued for another sort")
+	}
+
+	return cursor, nil
+}
+
+func pageSizeFromPb(pageSize int32) (int64, error) {
+	switch {
+	case pageSize < 0:
+		return 0, status.Error(codes.InvalidArgument, "page size must not be negative")
+	case pageSize == 0:
+		return defaultPageSize, nil
+	case pageSize > maxPageSize:
+		return maxPageSize, nil
+	}
+	return int64(pageSize), nil
+}
+
+// dealSortValue formats the sort field of a deal for a page cursor.
+func dealSortValue(deal *Deal, field string) string {
+	switch field {
+	case "delta_percent":
+		return deal.DeltaPercent.String()
+	case "amount":
+		return deal.Amount.String()
+	}
+	return deal.CreatedAt.UTC().Format(time.RFC3339Nano)
+}
+
+// parseDealSortValue is the inverse of dealSortValue, the result is the type
+// the field is stored with so that Mongo compares it in the same order.
+func parseDealSortValue(value string, field string) (interface{}, error) {
+	switch field {
+	case "delta_percent", "amount":
+		d, err := decimal.NewFromString(value)
+		if err != nil {
+			return nil, errInvalidPageToken
+		}
+		return d, nil
+	case "created_at":
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return nil, errInvalidPageToken
+		}
+		return t, nil
+	}
+	return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unknown sort field %s", field))
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PaperTrader opens and closes deals of paper symbols against simulated fills
+// taken from a PriceSource, no funds are ever touched.
+type PaperTrader struct {
+	storage paperStorage
+	prices  PriceSource
+}
+
+// paperStorage is the part of the Storage the paper trader uses.
+type paperStorage interface {
+	GetHalt(ctx context.Context) (*Halt, error)
+	GetTradingSymbol(ctx context.Context, symbol string) (*TradingSymbol, error)
+	CountDeals(ctx context.Context, symbol string) (int64, error)
+	CreateDeal(ctx context.Context, deal *Deal) error
+	DeleteDeal(ctx context.Context, deal *Deal) error
+	AddTradingSymbolBalance(ctx context.Context, symbol string, amount decimal.Decimal) error
+}
+
+var (
+	errSymbolNotPaper = func(symbol string) error {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("%s is not in paper trading", symbol))
+	}
+	errSymbolNotActive = func(symbol string) error {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("%s is not active", symbol))
+	}
+)
+
+func NewPaperTrader(
+	storage paperStorage,
+	prices PriceSource,
+) *PaperTrader {
+	return &PaperTrader{
+		storage: storage,
+		prices:  prices,
+	}
+}
+
+// OpenDeal fills a deal at the current price. A zero amount and an empty
+// prediction are taken from the config of the symbol.
+func (t *PaperTrader) OpenDeal(
+	ctx context.Context,
+	symbol string,
+	amountCurrency decimal.Decimal,
+	prediction DealPrediction,
+) (*Deal, error) {
+	halt, err := t.storage.GetHalt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if halt != nil {
+		return nil, errTradingHalted
+	}
+
+	tradingSymbol, err := t.storage.GetTradingSymbol(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	if tradingSymbol == nil {
+		return nil, errSymbolNotFound(symbol)
+	}
+	if !tradingSymbol.Paper {
+		return nil, errSymbolNotPaper(symbol)
+	}
+	if tradingSymbol.Status != pb.TradingSymbol_ACTIVE {
+		return nil, errSymbolNotActive(symbol)
+	}
+
+	config := tradingSymbol.Config
+	if amountCurrency.IsZero() {
+		amountCurrency = config.OrderSize
+	}
+	if !amountCurrency.IsPositive() {
+		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
+	}
+	if prediction == (DealPrediction{}) {
+		prediction = DealPrediction{decimalToFloat(config.StopPercent), decimalToFloat(config.MaxPercent)}
+	}
+
+	if config.MaxDeals > 0 {
+		open, err := t.storage.CountDeals(ctx, symbol)
+		if err != nil {
+			return nil, err
+		}
+		if open >= int64(config.MaxDeals) {
+			return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("%s has %d open deals already", symbol, open))
+		}
+	}
+
+	price, err := t.prices.GetPrice(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	if !price.IsPositive() {
+		return nil, errPriceNotFound(symbol)
+	}
+
+	now := time.Now()
+	deal := &Deal{
+		Id:             fmt.Sprintf("p-%d-%s", now.UnixNano(), symbol),
+		Symbol:         symbol,
+		CreatedAt:      now,
+		Amount:         amountCurrency.Div(price),
+		AmountCurrency: amountCurrency,
+		Prediction:     prediction,
+		Paper:          true,
+		OpenPrice:      price,
+		Timeframe:      config.Timeframe,
+	}
+	if err := t.storage.CreateDeal(ctx, deal); err != nil {
+		return nil, err
+	}
+
+	return deal, nil
+}
+
+// CloseDeal fills the deal at the current price and books its result to the
+// simulated balance of the symbol. The balance is kept in the base currency, so
+// the result is converted at the fill price. The deal is removed first, so a
+// deal closed concurrently is booked once.
+func (t *PaperTrader) CloseDeal(ctx context.Context, deal *Deal) error {
+	price, err := t.prices.GetPrice(ctx, deal.Symbol)
+	if err != nil {
+		return err
+	}
+
+	deal.DeltaAmount = price.Sub(deal.OpenPrice).Mul(deal.Amount)
+	if deal.OpenPrice.IsPositive() {
+		deal.DeltaPercent = dealDeltaPercent(deal, price)
+	}
+
+	if err := t.storage.DeleteDeal(ctx, deal); err != nil {
+		return err
+	}
+	if !price.IsPositive() {
+		return nil
+	}
+
+	return t.storage.AddTradingSymbolBalance(ctx, deal.Symbol, deal.DeltaAmount.Div(price))
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// memoryPaperStorage keeps the symbols and deals of the paper trader in memory.
+type memoryPaperStorage struct {
+	halt    *Halt
+	symbols map[string]*TradingSymbol
+	deals   map[string]*Deal
+}
+
+func newMemoryPaperStorage(symbols ...*TradingSymbol) *memoryPaperStorage {
+	s := &memoryPaperStorage{
+		symbols: make(map[string]*TradingSymbol),
+		deals:   make(map[string]*Deal),
+	}
+	for _, symbol := range symbols {
+		s.symbols[symbol.Symbol] = symbol
+	}
+	return s
+}
+
+func (s *memoryPaperStorage) GetHalt(context.Context) (*Halt, error) {
+	return s.halt, nil
+}
+
+func (s *memoryPaperStorage) GetTradingSymbol(_ context.Context, symbol string) (*TradingSymbol, error) {
+	return s.symbols[symbol], nil
+}
+
+func (s *memoryPaperStorage) CountDeals(_ context.Context, symbol string) (int64, error) {
+	n := int64(0)
+	for _, deal := range s.deals {
+		if deal.Symbol == symbol {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *memoryPaperStorage) CreateDeal(_ context.Context, deal *Deal) error {
+	deal.Version = 1
+	stored := *deal
+	s.deals[deal.Id] = &stored
+	return nil
+}
+
+func (s *memoryPaperStorage) DeleteDeal(_ context.Context, deal *Deal) error {
+	stored, ok := s.deals[deal.Id]
+	if !ok || stored.Version != deal.Version {
+		return errVersionConflict("deal", deal.Id)
+	}
+	delete(s.deals, deal.Id)
+	return nil
+}
+
+func (s *memoryPaperStorage) AddTradingSymbolBalance(_ context.Context, symbol string, amount decimal.Decimal) error {
+	s.symbols[symbol].Balance = s.symbols[symbol].Balance.Add(amount)
+	return nil
+}
+
+func paperSymbol(symbol string) *TradingSymbol {
+	return &TradingSymbol{
+		Symbol:  symbol,
+		Status:  pb.TradingSymbol_ACTIVE,
+		Balance: decimal.Zero,
+		Paper:   true,
+		Config: SymbolConfig{
+			Timeframe:   "1h",
+			StopPercent: decimal.NewFromInt(-5),
+			MaxPercent:  decimal.NewFromInt(20),
+			MaxDeals:    1,
+			OrderSize:   decimal.NewFromInt(100),
+		},
+	}
+}
+
+func newReplayTrader(t *testing.T, storage paperStorage, records string) *PaperTrader {
+	t.Helper()
+
+	prices, err := NewReplayPriceSource(strings.NewReader(records))
+	if err != nil {
+		t.Fatalf("cannot read replay prices: %v", err)
+	}
+	return NewPaperTrader(storage, prices)
+}
+
+func assertDecimal(t *testing.T, name string, got decimal.Decimal, want string) {
+	t.Helper()
+
+	if !got.Equal(decimal.RequireFromString(want)) {
+		t.Errorf("%s = %s, want %s", name, got, want)
+	}
+}
+
+func TestPaperTraderReplay(t *testing.T) {
+	ctx := context.Background()
+	storage := newMemoryPaperStorage(paperSymbol("adausdt"))
+	trader := newReplayTrader(t, storage, `# timestamp,symbol,price
+1609459200,adausdt,2.00
+1609459260,adausdt,1.60
+1609459320,adausdt,1.00
+1609459380,adausdt,1.25
+`)
+
+	// the first deal is filled at 2.00 and closed at 1.60, past its stop loss
+	deal, err := trader.OpenDeal(ctx, "adausdt", decimal.Zero, DealPrediction{})
+	if err != nil {
+		t.Fatalf("cannot open deal: %v", err)
+	}
+	if deal.Prediction != (DealPrediction{-5, 20}) {
+		t.Errorf("prediction = %v, want the config of the symbol", deal.Prediction)
+	}
+	assertDecimal(t, "open price", deal.OpenPrice, "2")
+	assertDecimal(t, "amount", deal.Amount, "50")
+	assertDecimal(t, "amount currency", deal.AmountCurrency, "100")
+
+	if _, err := trader.OpenDeal(ctx, "adausdt", decimal.Zero, DealPrediction{}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("deal over max deals: got %v, want FailedPrecondition", err)
+	}
+
+	if err := trader.CloseDeal(ctx, deal); err != nil {
+		t.Fatalf("cannot close deal: %v", err)
+	}
+	assertDecimal(t, "stop loss delta amount", deal.DeltaAmount, "-20")
+	assertDecimal(t, "stop loss delta percent", deal.DeltaPercent, "-20")
+	if deal.DeltaPercent.GreaterThan(decimal.NewFromFloat32(deal.Prediction.Stop)) {
+		t.Errorf("delta percent %s doesn't hit the stop %v", deal.DeltaPercent, deal.Prediction.Stop)
+	}
+	// the loss is booked in the base currency at the fill price
+	assertDecimal(t, "balance after stop loss", storage.symbols["adausdt"].Balance, "-12.5")
+
+	// the second deal is filled at 1.00 and closed at 1.25, past its take profit
+	deal, err = trader.OpenDeal(ctx, "adausdt", decimal.Zero, DealPrediction{})
+	if err != nil {
+		t.Fatalf("cannot open deal: %v", err)
+	}
+	assertDecimal(t, "amount", deal.Amount, "100")
+
+	if err := trader.CloseDeal(ctx, deal); err != nil {
+		t.Fatalf("cannot close deal: %v", err)
+	}
+	assertDecimal(t, "take profit delta amount", deal.DeltaAmount, "25")
+	assertDecimal(t, "take profit delta percent", deal.DeltaPercent, "25")
+	if deal.DeltaPercent.LessThan(decimal.NewFromFloat32(deal.Prediction.Max)) {
+		t.Errorf("delta percent %s doesn't hit the max %v", deal.DeltaPercent, deal.Prediction.Max)
+	}
+	assertDecimal(t, "balance after take profit", storage.symbols["adausdt"].Balance, "7.5")
+
+	// the deal is gone, so closing it again books nothing
+	if err := trader.CloseDeal(ctx, deal); status.Code(err) != codes.Aborted {
+		t.Errorf("closing a closed deal: got %v, want Aborted", err)
+	}
+	assertDecimal(t, "balance after closing twice", storage.symbols["adausdt"].Balance, "7.5")
+
+	if len(storage.deals) != 0 {
+		t.Errorf("%d deals are still open", len(storage.deals))
+	}
+}
+
+func TestPaperTraderReplayRepeatsLastPrice(t *testing.T) {
+	ctx := context.Background()
+	symbol := paperSymbol("ethusdt")
+	symbol.Config.MaxDeals = 0
+	storage := newMemoryPaperStorage(symbol)
+	trader := newReplayTrader(t, storage, "1609459200,ethusdt,700\n")
+
+	for i := 0; i < 3; i++ {
+		deal, err := trader.OpenDeal(ctx, "ethusdt", decimal.NewFromInt(350), DealPrediction{-1, 1})
+		if err != nil {
+			t.Fatalf("cannot open deal %d: %v", i, err)
+		}
+		assertDecimal(t, "open price", deal.OpenPrice, "700")
+		assertDecimal(t, "amount", deal.Amount, "0.5")
+		if deal.Prediction != (DealPrediction{-1, 1}) {
+			t.Errorf("prediction = %v, want the one given", deal.Prediction)
+		}
+
+		if err := trader.CloseDeal(ctx, deal); err != nil {
+			t.Fatalf("cannot close deal %d: %v", i, err)
+		}
+		assertDecimal(t, "delta amount", deal.DeltaAmount, "0")
+	}
+	assertDecimal(t, "balance", storage.symbols["ethusdt"].Balance, "0")
+}
+
+func TestPaperTraderRejects(t *testing.T) {
+	ctx := context.Background()
+	records := "1609459200,adausdt,2.00\n"
+
+	tests := []struct {
+		name   string
+		symbol string
+		setup  func(storage *memoryPaperStorage)
+		code   codes.Code
+	}{
+		{"unknown symbol", "dotusdt", func(*memoryPaperStorage) {}, codes.NotFound},
+		{"halted", "adausdt", func(s *memoryPaperStorage) { s.halt = &Halt{} }, codes.FailedPrecondition},
+		{"real symbol", "adausdt", func(s *memoryPaperStorage) { s.symbols["adausdt"].Paper = false }, codes.FailedPrecondition},
+		{"suspended", "adausdt", func(s *memoryPaperStorage) {
+			s.symbols["adausdt"].Status = pb.TradingSymbol_SUSPENDED
+		}, codes.FailedPrecondition},
+		{"no price", "dotusdt", func(s *memoryPaperStorage) {
+			s.symbols["dotusdt"] = paperSymbol("dotusdt")
+		}, codes.Unavailable},
+	}
+
+	for _, test := range tests {
+		storage := newMemoryPaperStorage(paperSymbol("adausdt"))
+		test.setup(storage)
+		trader := newReplayTrader(t, storage, records)
+
+		if _, err := trader.OpenDeal(ctx, test.symbol, decimal.Zero, DealPrediction{}); status.Code(err) != test.code {
+			t.Errorf("%s: got %v, want %s", test.name, err, test.code)
+		}
+	}
+}
@@ -79,6 +79,147 @@ func (TradingSymbol_TradingStatus) EnumDescriptor() ([]byte, []int) {
 	return file_pb_service_proto_rawDescGZIP(), []int{2, 0}
 }
 
+type BalanceHistoryRequest_Interval int32
+
+const (
+	BalanceHistoryRequest_HOUR BalanceHistoryRequest_Interval = 0
+	BalanceHistoryRequest_DAY  BalanceHistoryRequest_Interval = 1
+)
+
+// Enum value maps for BalanceHistoryRequest_Interval.
+var (
+	BalanceHistoryRequest_Interval_name = map[int32]string{
+		0: "HOUR",
+		1: "DAY",
+	}
+	BalanceHistoryRequest_Interval_value = map[string]int32{
+		"HOUR": 0,
+		"DAY":  1,
+	}
+)
+
+func (x BalanceHistoryRequest_Interval) Enum() *BalanceHistoryRequest_Interval {
+	p := new(BalanceHistoryRequest_Interval)
+	*p = x
+	return p
+}
+
+func (x BalanceHistoryRequest_Interval) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BalanceHistoryRequest_Interval) Descriptor() protoreflect.EnumDescriptor {
+	return file_pb_service_proto_enumTypes[1].Descriptor()
+}
+
+func (BalanceHistoryRequest_Interval) Type() protoreflect.EnumType {
+	return &file_pb_service_proto_enumTypes[1]
+}
+
+func (x BalanceHistoryRequest_Interval) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BalanceHistoryRequest_Interval.Descriptor instead.
+func (BalanceHistoryRequest_Interval) EnumDescriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{11, 0}
+}
+
+type DealsRequest_SortField int32
+
+const (
+	DealsRequest_CREATED_AT    DealsRequest_SortField = 0
+	DealsRequest_DELTA_PERCENT DealsRequest_SortField = 1
+	DealsRequest_AMOUNT        DealsRequest_SortField = 2
+)
+
+// Enum value maps for DealsRequest_SortField.
+var (
+	DealsRequest_SortField_name = map[int32]string{
+		0: "CREATED_AT",
+		1: "DELTA_PERCENT",
+		2: "AMOUNT",
+	}
+	DealsRequest_SortField_value = map[string]int32{
+		"CREATED_AT":    0,
+		"DELTA_PERCENT": 1,
+		"AMOUNT":        2,
+	}
+)
+
+func (x DealsRequest_SortField) Enum() *DealsRequest_SortField {
+	p := new(DealsRequest_SortField)
+	*p = x
+	return p
+}
+
+func (x DealsRequest_SortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DealsRequest_SortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_pb_service_proto_enumTypes[2].Descriptor()
+}
+
+func (DealsRequest_SortField) Type() protoreflect.EnumType {
+	return &file_pb_service_proto_enumTypes[2]
+}
+
+func (x DealsRequest_SortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DealsRequest_SortField.Descriptor instead.
+func (DealsRequest_SortField) EnumDescriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{18, 0}
+}
+
+type ExportDealsRequest_Format int32
+
+const (
+	ExportDealsRequest_CSV  ExportDealsRequest_Format = 0
+	ExportDealsRequest_XLSX ExportDealsRequest_Format = 1
+)
+
+// Enum value maps for ExportDealsRequest_Format.
+var (
+	ExportDealsRequest_Format_name = map[int32]string{
+		0: "CSV",
+		1: "XLSX",
+	}
+	ExportDealsRequest_Format_value = map[string]int32{
+		"CSV":  0,
+		"XLSX": 1,
+	}
+)
+
+func (x ExportDealsRequest_Format) Enum() *ExportDealsRequest_Format {
+	p := new(ExportDealsRequest_Format)
+	*p = x
+	return p
+}
+
+func (x ExportDealsRequest_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportDealsRequest_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_pb_service_proto_enumTypes[3].Descriptor()
+}
+
+func (ExportDealsRequest_Format) Type() protoreflect.EnumType {
+	return &file_pb_service_proto_enumTypes[3]
+}
+
+func (x ExportDealsRequest_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportDealsRequest_Format.Descriptor instead.
+func (ExportDealsRequest_Format) EnumDescriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{41, 0}
+}
+
 type EmptyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -169,8 +310,12 @@ type TradingSymbol struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Symbol string                      `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Status TradingSymbol_TradingStatus `protobuf:"varint,3,opt,name=status,proto3,enum=gandalf.TradingSymbol_TradingStatus" json:"status,omitempty"`
+	Symbol        string                      `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Status        TradingSymbol_TradingStatus `protobuf:"varint,3,opt,name=status,proto3,enum=gandalf.TradingSymbol_TradingStatus" json:"status,omitempty"`
+	Paper         bool                        `protobuf:"varint,5,opt,name=paper,proto3" json:"paper,omitempty"`
+	SuspendReason string                      `protobuf:"bytes,7,opt,name=suspendReason,proto3" json:"suspendReason,omitempty"` // set when suspended automatically, e.g. by the drawdown circuit breaker
+	Config        *SymbolConfig               `protobuf:"bytes,9,opt,name=config,proto3" json:"config,omitempty"`
+	Version       int64                       `protobuf:"varint,11,opt,name=version,proto3" json:"version,omitempty"` // increased on every change of the symbol
 }
 
 func (x *TradingSymbol) Reset() {
@@ -219,16 +364,52 @@ func (x *TradingSymbol) GetStatus() TradingSymbol_TradingStatus {
 	return TradingSymbol_PREPARING
 }
 
-type TradingSymbolsResponse struct {
+func (x *TradingSymbol) GetPaper() bool {
+	if x != nil {
+		return x.Paper
+	}
+	return false
+}
+
+func (x *TradingSymbol) GetSuspendReason() string {
+	if x != nil {
+		return x.SuspendReason
+	}
+	return ""
+}
+
+func (x *TradingSymbol) GetConfig() *SymbolConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *TradingSymbol) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type SymbolConfig struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Symbols []*TradingSymbol `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"`
+	Version            int32                `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`                    // increased on every change, ignored on set
+	Timeframe          string               `protobuf:"bytes,3,opt,name=timeframe,proto3" json:"timeframe,omitempty"`                 // 1min, 5min, 15min, 30min, 60min, 4hour or 1day
+	EntryDeltaPercent  string               `protobuf:"bytes,5,opt,name=entryDeltaPercent,proto3" json:"entryDeltaPercent,omitempty"` // positive
+	StopPercent        string               `protobuf:"bytes,7,opt,name=stopPercent,proto3" json:"stopPercent,omitempty"`             // negative, default prediction stop of deals
+	MaxPercent         string               `protobuf:"bytes,9,opt,name=maxPercent,proto3" json:"maxPercent,omitempty"`               // positive, default prediction max of deals
+	MaxConcurrentDeals int32                `protobuf:"varint,11,opt,name=maxConcurrentDeals,proto3" json:"maxConcurrentDeals,omitempty"`
+	OrderSize          string               `protobuf:"bytes,13,opt,name=orderSize,proto3" json:"orderSize,omitempty"` // quote currency amount of a deal
+	UpdatedBy          int64                `protobuf:"varint,15,opt,name=updatedBy,proto3" json:"updatedBy,omitempty"`
+	UpdatedAt          *timestamp.Timestamp `protobuf:"bytes,17,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
 }
 
-func (x *TradingSymbolsResponse) Reset() {
-	*x = TradingSymbolsResponse{}
+func (x *SymbolConfig) Reset() {
+	*x = SymbolConfig{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_service_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -236,13 +417,13 @@ func (x *TradingSymbolsResponse) Reset() {
 	}
 }
 
-func (x *TradingSymbolsResponse) String() string {
+func (x *SymbolConfig) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TradingSymbolsResponse) ProtoMessage() {}
+func (*SymbolConfig) ProtoMessage() {}
 
-func (x *TradingSymbolsResponse) ProtoReflect() protoreflect.Message {
+func (x *SymbolConfig) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_service_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -254,29 +435,86 @@ func (x *TradingSymbolsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TradingSymbolsResponse.ProtoReflect.Descriptor instead.
-func (*TradingSymbolsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SymbolConfig.ProtoReflect.Descriptor instead.
+func (*SymbolConfig) Descriptor() ([]byte, []int) {
 	return file_pb_service_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *TradingSymbolsResponse) GetSymbols() []*TradingSymbol {
+func (x *SymbolConfig) GetVersion() int32 {
 	if x != nil {
-		return x.Symbols
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SymbolConfig) GetTimeframe() string {
+	if x != nil {
+		return x.Timeframe
+	}
+	return ""
+}
+
+func (x *SymbolConfig) GetEntryDeltaPercent() string {
+	if x != nil {
+		return x.EntryDeltaPercent
+	}
+	return ""
+}
+
+func (x *SymbolConfig) GetStopPercent() string {
+	if x != nil {
+		return x.StopPercent
+	}
+	return ""
+}
+
+func (x *SymbolConfig) GetMaxPercent() string {
+	if x != nil {
+		return x.MaxPercent
+	}
+	return ""
+}
+
+func (x *SymbolConfig) GetMaxConcurrentDeals() int32 {
+	if x != nil {
+		return x.MaxConcurrentDeals
+	}
+	return 0
+}
+
+func (x *SymbolConfig) GetOrderSize() string {
+	if x != nil {
+		return x.OrderSize
+	}
+	return ""
+}
+
+func (x *SymbolConfig) GetUpdatedBy() int64 {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return 0
+}
+
+func (x *SymbolConfig) GetUpdatedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
 	}
 	return nil
 }
 
-type SymbolRequest struct {
+type TradingSymbolsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId int64  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
-	Symbol string `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	UserId    int64  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	PageSize  int32  `protobuf:"varint,3,opt,name=pageSize,proto3" json:"pageSize,omitempty"`  // 100 if not set, at most 1000
+	PageToken string `protobuf:"bytes,5,opt,name=pageToken,proto3" json:"pageToken,omitempty"` // nextPageToken of the previous page
 }
 
-func (x *SymbolRequest) Reset() {
-	*x = SymbolRequest{}
+func (x *TradingSymbolsRequest) Reset() {
+	*x = TradingSymbolsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_service_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -284,13 +522,13 @@ func (x *SymbolRequest) Reset() {
 	}
 }
 
-func (x *SymbolRequest) String() string {
+func (x *TradingSymbolsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SymbolRequest) ProtoMessage() {}
+func (*TradingSymbolsRequest) ProtoMessage() {}
 
-func (x *SymbolRequest) ProtoReflect() protoreflect.Message {
+func (x *TradingSymbolsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_service_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -302,36 +540,44 @@ func (x *SymbolRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SymbolRequest.ProtoReflect.Descriptor instead.
-func (*SymbolRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TradingSymbolsRequest.ProtoReflect.Descriptor instead.
+func (*TradingSymbolsRequest) Descriptor() ([]byte, []int) {
 	return file_pb_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *SymbolRequest) GetUserId() int64 {
+func (x *TradingSymbolsRequest) GetUserId() int64 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-func (x *SymbolRequest) GetSymbol() string {
+func (x *TradingSymbolsRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.Symbol
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *TradingSymbolsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
 	}
 	return ""
 }
 
-type SymbolBalance struct {
+type TradingSymbolsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Symbol string  `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Amount float32 `protobuf:"fixed32,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Symbols       []*TradingSymbol `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"`             // ordered by symbol
+	Halted        bool             `protobuf:"varint,3,opt,name=halted,proto3" json:"halted,omitempty"`              // set by EmergencyHalt until EmergencyResume
+	NextPageToken string           `protobuf:"bytes,5,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // empty on the last page
 }
 
-func (x *SymbolBalance) Reset() {
-	*x = SymbolBalance{}
+func (x *TradingSymbolsResponse) Reset() {
+	*x = TradingSymbolsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_service_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -339,13 +585,13 @@ func (x *SymbolBalance) Reset() {
 	}
 }
 
-func (x *SymbolBalance) String() string {
+func (x *TradingSymbolsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SymbolBalance) ProtoMessage() {}
+func (*TradingSymbolsResponse) ProtoMessage() {}
 
-func (x *SymbolBalance) ProtoReflect() protoreflect.Message {
+func (x *TradingSymbolsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_service_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -357,35 +603,45 @@ func (x *SymbolBalance) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SymbolBalance.ProtoReflect.Descriptor instead.
-func (*SymbolBalance) Descriptor() ([]byte, []int) {
+// Deprecated: Use TradingSymbolsResponse.ProtoReflect.Descriptor instead.
+func (*TradingSymbolsResponse) Descriptor() ([]byte, []int) {
 	return file_pb_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *SymbolBalance) GetSymbol() string {
+func (x *TradingSymbolsResponse) GetSymbols() []*TradingSymbol {
 	if x != nil {
-		return x.Symbol
+		return x.Symbols
 	}
-	return ""
+	return nil
 }
 
-func (x *SymbolBalance) GetAmount() float32 {
+func (x *TradingSymbolsResponse) GetHalted() bool {
 	if x != nil {
-		return x.Amount
+		return x.Halted
 	}
-	return 0
+	return false
 }
 
-type SymbolBalancesResponse struct {
+func (x *TradingSymbolsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type SymbolRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Balances []*SymbolBalance `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	UserId int64         `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Symbol string        `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Paper  bool          `protobuf:"varint,5,opt,name=paper,proto3" json:"paper,omitempty"`  // only used by SymbolTradingPrepare
+	Config *SymbolConfig `protobuf:"bytes,7,opt,name=config,proto3" json:"config,omitempty"` // only used by SymbolTradingPrepare, empty fields take the defaults
 }
 
-func (x *SymbolBalancesResponse) Reset() {
-	*x = SymbolBalancesResponse{}
+func (x *SymbolRequest) Reset() {
+	*x = SymbolRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_service_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -393,13 +649,13 @@ func (x *SymbolBalancesResponse) Reset() {
 	}
 }
 
-func (x *SymbolBalancesResponse) String() string {
+func (x *SymbolRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SymbolBalancesResponse) ProtoMessage() {}
+func (*SymbolRequest) ProtoMessage() {}
 
-func (x *SymbolBalancesResponse) ProtoReflect() protoreflect.Message {
+func (x *SymbolRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_service_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -411,29 +667,50 @@ func (x *SymbolBalancesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SymbolBalancesResponse.ProtoReflect.Descriptor instead.
-func (*SymbolBalancesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SymbolRequest.ProtoReflect.Descriptor instead.
+func (*SymbolRequest) Descriptor() ([]byte, []int) {
 	return file_pb_service_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *SymbolBalancesResponse) GetBalances() []*SymbolBalance {
+func (x *SymbolRequest) GetUserId() int64 {
 	if x != nil {
-		return x.Balances
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SymbolRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *SymbolRequest) GetPaper() bool {
+	if x != nil {
+		return x.Paper
+	}
+	return false
+}
+
+func (x *SymbolRequest) GetConfig() *SymbolConfig {
+	if x != nil {
+		return x.Config
 	}
 	return nil
 }
 
-type SymbolLimit struct {
+type SymbolBalancesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Symbol string  `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Limit  float32 `protobuf:"fixed32,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	UserId        int64  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	QuoteCurrency string `protobuf:"bytes,3,opt,name=quoteCurrency,proto3" json:"quoteCurrency,omitempty"` // usdt if empty
 }
 
-func (x *SymbolLimit) Reset() {
-	*x = SymbolLimit{}
+func (x *SymbolBalancesRequest) Reset() {
+	*x = SymbolBalancesRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_service_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -441,13 +718,13 @@ func (x *SymbolLimit) Reset() {
 	}
 }
 
-func (x *SymbolLimit) String() string {
+func (x *SymbolBalancesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SymbolLimit) ProtoMessage() {}
+func (*SymbolBalancesRequest) ProtoMessage() {}
 
-func (x *SymbolLimit) ProtoReflect() protoreflect.Message {
+func (x *SymbolBalancesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_service_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -459,36 +736,41 @@ func (x *SymbolLimit) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SymbolLimit.ProtoReflect.Descriptor instead.
-func (*SymbolLimit) Descriptor() ([]byte, []int) {
+// Deprecated: Use SymbolBalancesRequest.ProtoReflect.Descriptor instead.
+func (*SymbolBalancesRequest) Descriptor() ([]byte, []int) {
 	return file_pb_service_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *SymbolLimit) GetSymbol() string {
+func (x *SymbolBalancesRequest) GetUserId() int64 {
 	if x != nil {
-		return x.Symbol
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-func (x *SymbolLimit) GetLimit() float32 {
+func (x *SymbolBalancesRequest) GetQuoteCurrency() string {
 	if x != nil {
-		return x.Limit
+		return x.QuoteCurrency
 	}
-	return 0
+	return ""
 }
 
-type GetSymbolLimitsRequest struct {
+type SymbolBalance struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId  int64    `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
-	Symbols []string `protobuf:"bytes,3,rep,name=symbols,proto3" json:"symbols,omitempty"`
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Deprecated: Do not use.
+	Amount float32 `protobuf:"fixed32,3,opt,name=amount,proto3" json:"amount,omitempty"` // use amountDecimal
+	// Deprecated: Do not use.
+	QuoteValue        float32 `protobuf:"fixed32,5,opt,name=quoteValue,proto3" json:"quoteValue,omitempty"`             // use quoteValueDecimal
+	AmountDecimal     string  `protobuf:"bytes,7,opt,name=amountDecimal,proto3" json:"amountDecimal,omitempty"`         // native amount of the base currency
+	QuoteValueDecimal string  `protobuf:"bytes,9,opt,name=quoteValueDecimal,proto3" json:"quoteValueDecimal,omitempty"` // empty if the balance can't be valued in the quote currency, then it isn't in the totals
 }
 
-func (x *GetSymbolLimitsRequest) Reset() {
-	*x = GetSymbolLimitsRequest{}
+func (x *SymbolBalance) Reset() {
+	*x = SymbolBalance{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_service_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -496,13 +778,13 @@ func (x *GetSymbolLimitsRequest) Reset() {
 	}
 }
 
-func (x *GetSymbolLimitsRequest) String() string {
+func (x *SymbolBalance) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSymbolLimitsRequest) ProtoMessage() {}
+func (*SymbolBalance) ProtoMessage() {}
 
-func (x *GetSymbolLimitsRequest) ProtoReflect() protoreflect.Message {
+func (x *SymbolBalance) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_service_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -514,36 +796,66 @@ func (x *GetSymbolLimitsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSymbolLimitsRequest.ProtoReflect.Descriptor instead.
-func (*GetSymbolLimitsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SymbolBalance.ProtoReflect.Descriptor instead.
+func (*SymbolBalance) Descriptor() ([]byte, []int) {
 	return file_pb_service_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *GetSymbolLimitsRequest) GetUserId() int64 {
+func (x *SymbolBalance) GetSymbol() string {
 	if x != nil {
-		return x.UserId
+		return x.Symbol
+	}
+	return ""
+}
+
+// Deprecated: Do not use.
+func (x *SymbolBalance) GetAmount() float32 {
+	if x != nil {
+		return x.Amount
 	}
 	return 0
 }
 
-func (x *GetSymbolLimitsRequest) GetSymbols() []string {
+// Deprecated: Do not use.
+func (x *SymbolBalance) GetQuoteValue() float32 {
 	if x != nil {
-		return x.Symbols
+		return x.QuoteValue
 	}
-	return nil
+	return 0
 }
 
-type SetSymbolLimitsRequest struct {
+func (x *SymbolBalance) GetAmountDecimal() string {
+	if x != nil {
+		return x.AmountDecimal
+	}
+	return ""
+}
+
+func (x *SymbolBalance) GetQuoteValueDecimal() string {
+	if x != nil {
+		return x.QuoteValueDecimal
+	}
+	return ""
+}
+
+type SymbolBalancesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId int64          `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
-	Limits []*SymbolLimit `protobuf:"bytes,3,rep,name=limits,proto3" json:"limits,omitempty"`
+	Balances      []*SymbolBalance `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	PaperBalances []*SymbolBalance `protobuf:"bytes,3,rep,name=paperBalances,proto3" json:"paperBalances,omitempty"`
+	QuoteCurrency string           `protobuf:"bytes,5,opt,name=quoteCurrency,proto3" json:"quoteCurrency,omitempty"`
+	// Deprecated: Do not use.
+	Total float32 `protobuf:"fixed32,7,opt,name=total,proto3" json:"total,omitempty"` // use totalDecimal
+	// Deprecated: Do not use.
+	PaperTotal        float32 `protobuf:"fixed32,9,opt,name=paperTotal,proto3" json:"paperTotal,omitempty"` // use paperTotalDecimal
+	TotalDecimal      string  `protobuf:"bytes,11,opt,name=totalDecimal,proto3" json:"totalDecimal,omitempty"`
+	PaperTotalDecimal string  `protobuf:"bytes,13,opt,name=paperTotalDecimal,proto3" json:"paperTotalDecimal,omitempty"`
 }
 
-func (x *SetSymbolLimitsRequest) Reset() {
-	*x = SetSymbolLimitsRequest{}
+func (x *SymbolBalancesResponse) Reset() {
+	*x = SymbolBalancesResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_service_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -551,13 +863,13 @@ func (x *SetSymbolLimitsRequest) Reset() {
 	}
 }
 
-func (x *SetSymbolLimitsRequest) String() string {
+func (x *SymbolBalancesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SetSymbolLimitsRequest) ProtoMessage() {}
+func (*SymbolBalancesResponse) ProtoMessage() {}
 
-func (x *SetSymbolLimitsRequest) ProtoReflect() protoreflect.Message {
+func (x *SymbolBalancesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_service_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -569,35 +881,76 @@ func (x *SetSymbolLimitsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SetSymbolLimitsRequest.ProtoReflect.Descriptor instead.
-func (*SetSymbolLimitsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SymbolBalancesResponse.ProtoReflect.Descriptor instead.
+func (*SymbolBalancesResponse) Descriptor() ([]byte, []int) {
 	return file_pb_service_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *SetSymbolLimitsRequest) GetUserId() int64 {
+func (x *SymbolBalancesResponse) GetBalances() []*SymbolBalance {
 	if x != nil {
-		return x.UserId
+		return x.Balances
 	}
-	return 0
+	return nil
 }
 
-func (x *SetSymbolLimitsRequest) GetLimits() []*SymbolLimit {
+func (x *SymbolBalancesResponse) GetPaperBalances() []*SymbolBalance {
 	if x != nil {
-		return x.Limits
+		return x.PaperBalances
 	}
 	return nil
 }
 
-type SymbolLimitsResponse struct {
+func (x *SymbolBalancesResponse) GetQuoteCurrency() string {
+	if x != nil {
+		return x.QuoteCurrency
+	}
+	return ""
+}
+
+// Deprecated: Do not use.
+func (x *SymbolBalancesResponse) GetTotal() float32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// Deprecated: Do not use.
+func (x *SymbolBalancesResponse) GetPaperTotal() float32 {
+	if x != nil {
+		return x.PaperTotal
+	}
+	return 0
+}
+
+func (x *SymbolBalancesResponse) GetTotalDecimal() string {
+	if x != nil {
+		return x.TotalDecimal
+	}
+	return ""
+}
+
+func (x *SymbolBalancesResponse) GetPaperTotalDecimal() string {
+	if x != nil {
+		return x.PaperTotalDecimal
+	}
+	return ""
+}
+
+type SymbolLimit struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Limits []*SymbolLimit `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits,omitempty"`
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Deprecated: Do not use.
+	Limit              float32 `protobuf:"fixed32,3,opt,name=limit,proto3" json:"limit,omitempty"` // use limitDecimal
+	LimitDecimal       string  `protobuf:"bytes,5,opt,name=limitDecimal,proto3" json:"limitDecimal,omitempty"`
+	MaxDrawdownDecimal string  `protobuf:"bytes,7,opt,name=maxDrawdownDecimal,proto3" json:"maxDrawdownDecimal,omitempty"` // loss over the drawdown window that suspends the symbol, disabled if zero, unchanged if empty on set
 }
 
-func (x *SymbolLimitsResponse) Reset() {
-	*x = SymbolLimitsResponse{}
+func (x *SymbolLimit) Reset() {
+	*x = SymbolLimit{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_service_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -605,13 +958,13 @@ func (x *SymbolLimitsResponse) Reset() {
 	}
 }
 
-func (x *SymbolLimitsResponse) String() string {
+func (x *SymbolLimit) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SymbolLimitsResponse) ProtoMessage() {}
+func (*SymbolLimit) ProtoMessage() {}
 
-func (x *SymbolLimitsResponse) ProtoReflect() protoreflect.Message {
+func (x *SymbolLimit) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_service_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -623,33 +976,56 @@ func (x *SymbolLimitsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SymbolLimitsResponse.ProtoReflect.Descriptor instead.
-func (*SymbolLimitsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SymbolLimit.ProtoReflect.Descriptor instead.
+func (*SymbolLimit) Descriptor() ([]byte, []int) {
 	return file_pb_service_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *SymbolLimitsResponse) GetLimits() []*SymbolLimit {
+func (x *SymbolLimit) GetSymbol() string {
 	if x != nil {
-		return x.Limits
+		return x.Symbol
 	}
-	return nil
+	return ""
 }
 
-type DealsRequest struct {
+// Deprecated: Do not use.
+func (x *SymbolLimit) GetLimit() float32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SymbolLimit) GetLimitDecimal() string {
+	if x != nil {
+		return x.LimitDecimal
+	}
+	return ""
+}
+
+func (x *SymbolLimit) GetMaxDrawdownDecimal() string {
+	if x != nil {
+		return x.MaxDrawdownDecimal
+	}
+	return ""
+}
+
+type BalanceHistoryRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId   int64                `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
-	All      bool                 `protobuf:"varint,3,opt,name=all,proto3" json:"all,omitempty"`
-	Symbols  []string             `protobuf:"bytes,5,rep,name=symbols,proto3" json:"symbols,omitempty"`
-	DateFrom *timestamp.Timestamp `protobuf:"bytes,7,opt,name=dateFrom,proto3" json:"dateFrom,omitempty"`
-	DateTo   *timestamp.Timestamp `protobuf:"bytes,9,opt,name=dateTo,proto3" json:"dateTo,omitempty"`
-	DealIds  []string             `protobuf:"bytes,11,rep,name=dealIds,proto3" json:"dealIds,omitempty"`
+	UserId        int64                          `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Symbols       []string                       `protobuf:"bytes,3,rep,name=symbols,proto3" json:"symbols,omitempty"`   // all if empty
+	DateFrom      *timestamp.Timestamp           `protobuf:"bytes,5,opt,name=dateFrom,proto3" json:"dateFrom,omitempty"` // 7 days before dateTo if not set
+	DateTo        *timestamp.Timestamp           `protobuf:"bytes,7,opt,name=dateTo,proto3" json:"dateTo,omitempty"`     // now if not set
+	Interval      BalanceHistoryRequest_Interval `protobuf:"varint,9,opt,name=interval,proto3,enum=gandalf.BalanceHistoryRequest_Interval" json:"interval,omitempty"`
+	Timezone      string                         `protobuf:"bytes,11,opt,name=timezone,proto3" json:"timezone,omitempty"`           // IANA name of the timezone days start in, UTC if empty
+	QuoteCurrency string                         `protobuf:"bytes,13,opt,name=quoteCurrency,proto3" json:"quoteCurrency,omitempty"` // usdt if empty, only currencies snapshots are taken in have a history
 }
 
-func (x *DealsRequest) Reset() {
-	*x = DealsRequest{}
+func (x *BalanceHistoryRequest) Reset() {
+	*x = BalanceHistoryRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_service_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -657,13 +1033,13 @@ func (x *DealsRequest) Reset() {
 	}
 }
 
-func (x *DealsRequest) String() string {
+func (x *BalanceHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DealsRequest) ProtoMessage() {}
+func (*BalanceHistoryRequest) ProtoMessage() {}
 
-func (x *DealsRequest) ProtoReflect() protoreflect.Message {
+func (x *BalanceHistoryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_service_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -675,70 +1051,74 @@ func (x *DealsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DealsRequest.ProtoReflect.Descriptor instead.
-func (*DealsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BalanceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*BalanceHistoryRequest) Descriptor() ([]byte, []int) {
 	return file_pb_service_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *DealsRequest) GetUserId() int64 {
+func (x *BalanceHistoryRequest) GetUserId() int64 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-func (x *DealsRequest) GetAll() bool {
-	if x != nil {
-		return x.All
-	}
-	return false
-}
-
-func (x *DealsRequest) GetSymbols() []string {
+func (x *BalanceHistoryRequest) GetSymbols() []string {
 	if x != nil {
 		return x.Symbols
 	}
 	return nil
 }
 
-func (x *DealsRequest) GetDateFrom() *timestamp.Timestamp {
+func (x *BalanceHistoryRequest) GetDateFrom() *timestamp.Timestamp {
 	if x != nil {
 		return x.DateFrom
 	}
 	return nil
 }
 
-func (x *DealsRequest) GetDateTo() *timestamp.Timestamp {
+func (x *BalanceHistoryRequest) GetDateTo() *timestamp.Timestamp {
 	if x != nil {
 		return x.DateTo
 	}
 	return nil
 }
 
-func (x *DealsRequest) GetDealIds() []string {
+func (x *BalanceHistoryRequest) GetInterval() BalanceHistoryRequest_Interval {
 	if x != nil {
-		return x.DealIds
+		return x.Interval
 	}
-	return nil
+	return BalanceHistoryRequest_HOUR
 }
 
-type Deal struct {
+func (x *BalanceHistoryRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *BalanceHistoryRequest) GetQuoteCurrency() string {
+	if x != nil {
+		return x.QuoteCurrency
+	}
+	return ""
+}
+
+type BalancePoint struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DealId         string               `protobuf:"bytes,1,opt,name=dealId,proto3" json:"dealId,omitempty"` // possible format d-165738457656-adausdt or use Huobi's order id
-	Symbol         string               `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	CreatedAt      *timestamp.Timestamp `protobuf:"bytes,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
-	Amount         float32              `protobuf:"fixed32,7,opt,name=amount,proto3" json:"amount,omitempty"`
-	AmountCurrency float32              `protobuf:"fixed32,9,opt,name=amountCurrency,proto3" json:"amountCurrency,omitempty"`
-	DeltaAmount    float32              `protobuf:"fixed32,11,opt,name=deltaAmount,proto3" json:"deltaAmount,omitempty"`
-	DeltaPercent   float32              `protobuf:"fixed32,13,opt,name=deltaPercent,proto3" json:"deltaPercent,omitempty"`
-	Prediction     *Deal_DealPrediction `protobuf:"bytes,15,opt,name=prediction,proto3" json:"prediction,omitempty"`
+	Time                 *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`                                 // start of the interval
+	BalanceDecimal       string               `protobuf:"bytes,3,opt,name=balanceDecimal,proto3" json:"balanceDecimal,omitempty"`             // at the end of the interval, not set in totals
+	QuoteValueDecimal    string               `protobuf:"bytes,5,opt,name=quoteValueDecimal,proto3" json:"quoteValueDecimal,omitempty"`       // at the end of the interval
+	MinQuoteValueDecimal string               `protobuf:"bytes,7,opt,name=minQuoteValueDecimal,proto3" json:"minQuoteValueDecimal,omitempty"` // not set in totals
+	MaxQuoteValueDecimal string               `protobuf:"bytes,9,opt,name=maxQuoteValueDecimal,proto3" json:"maxQuoteValueDecimal,omitempty"` // not set in totals
 }
 
-func (x *Deal) Reset() {
-	*x = Deal{}
+func (x *BalancePoint) Reset() {
+	*x = BalancePoint{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_service_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -746,13 +1126,13 @@ func (x *Deal) Reset() {
 	}
 }
 
-func (x *Deal) String() string {
+func (x *BalancePoint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Deal) ProtoMessage() {}
+func (*BalancePoint) ProtoMessage() {}
 
-func (x *Deal) ProtoReflect() protoreflect.Message {
+func (x *BalancePoint) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_service_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -764,77 +1144,58 @@ func (x *Deal) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Deal.ProtoReflect.Descriptor instead.
-func (*Deal) Descriptor() ([]byte, []int) {
+// Deprecated: Use BalancePoint.ProtoReflect.Descriptor instead.
+func (*BalancePoint) Descriptor() ([]byte, []int) {
 	return file_pb_service_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *Deal) GetDealId() string {
-	if x != nil {
-		return x.DealId
-	}
-	return ""
-}
-
-func (x *Deal) GetSymbol() string {
-	if x != nil {
-		return x.Symbol
-	}
-	return ""
-}
-
-func (x *Deal) GetCreatedAt() *timestamp.Timestamp {
+func (x *BalancePoint) GetTime() *timestamp.Timestamp {
 	if x != nil {
-		return x.CreatedAt
+		return x.Time
 	}
 	return nil
 }
 
-func (x *Deal) GetAmount() float32 {
-	if x != nil {
-		return x.Amount
-	}
-	return 0
-}
-
-func (x *Deal) GetAmountCurrency() float32 {
+func (x *BalancePoint) GetBalanceDecimal() string {
 	if x != nil {
-		return x.AmountCurrency
+		return x.BalanceDecimal
 	}
-	return 0
+	return ""
 }
 
-func (x *Deal) GetDeltaAmount() float32 {
+func (x *BalancePoint) GetQuoteValueDecimal() string {
 	if x != nil {
-		return x.DeltaAmount
+		return x.QuoteValueDecimal
 	}
-	return 0
+	return ""
 }
 
-func (x *Deal) GetDeltaPercent() float32 {
+func (x *BalancePoint) GetMinQuoteValueDecimal() string {
 	if x != nil {
-		return x.DeltaPercent
+		return x.MinQuoteValueDecimal
 	}
-	return 0
+	return ""
 }
 
-func (x *Deal) GetPrediction() *Deal_DealPrediction {
+func (x *BalancePoint) GetMaxQuoteValueDecimal() string {
 	if x != nil {
-		return x.Prediction
+		return x.MaxQuoteValueDecimal
 	}
-	return nil
+	return ""
 }
 
-type DealsResponse struct {
+type BalanceHistory struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Deals []*Deal `protobuf:"bytes,1,rep,name=deals,proto3" json:"deals,omitempty"`
+	Symbol string          `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Paper  bool            `protobuf:"varint,3,opt,name=paper,proto3" json:"paper,omitempty"`
+	Points []*BalancePoint `protobuf:"bytes,5,rep,name=points,proto3" json:"points,omitempty"` // intervals without snapshots are left out
 }
 
-func (x *DealsResponse) Reset() {
-	*x = DealsResponse{}
+func (x *BalanceHistory) Reset() {
+	*x = BalanceHistory{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_service_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -842,13 +1203,13 @@ func (x *DealsResponse) Reset() {
 	}
 }
 
-func (x *DealsResponse) String() string {
+func (x *BalanceHistory) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DealsResponse) ProtoMessage() {}
+func (*BalanceHistory) ProtoMessage() {}
 
-func (x *DealsResponse) ProtoReflect() protoreflect.Message {
+func (x *BalanceHistory) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_service_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -860,34 +1221,44 @@ func (x *DealsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DealsResponse.ProtoReflect.Descriptor instead.
-func (*DealsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BalanceHistory.ProtoReflect.Descriptor instead.
+func (*BalanceHistory) Descriptor() ([]byte, []int) {
 	return file_pb_service_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *DealsResponse) GetDeals() []*Deal {
+func (x *BalanceHistory) GetSymbol() string {
 	if x != nil {
-		return x.Deals
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *BalanceHistory) GetPaper() bool {
+	if x != nil {
+		return x.Paper
+	}
+	return false
+}
+
+func (x *BalanceHistory) GetPoints() []*BalancePoint {
+	if x != nil {
+		return x.Points
 	}
 	return nil
 }
 
-type PotentialDeal struct {
+type BalanceHistoryResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Symbol      string  `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	ActualRate  float32 `protobuf:"fixed32,3,opt,name=actualRate,proto3" json:"actualRate,omitempty"`
-	PlanRate    float32 `protobuf:"fixed32,5,opt,name=planRate,proto3" json:"planRate,omitempty"`
-	Limit       float32 `protobuf:"fixed32,7,opt,name=limit,proto3" json:"limit,omitempty"`
-	TimeFrame   string  `protobuf:"bytes,9,opt,name=timeFrame,proto3" json:"timeFrame,omitempty"`
-	ActualDelta float32 `protobuf:"fixed32,11,opt,name=actualDelta,proto3" json:"actualDelta,omitempty"`
-	PlanDelta   float32 `protobuf:"fixed32,13,opt,name=planDelta,proto3" json:"planDelta,omitempty"`
+	Symbols       []*BalanceHistory `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"`
+	Totals        []*BalancePoint   `protobuf:"bytes,3,rep,name=totals,proto3" json:"totals,omitempty"` // sum of the real symbols with a snapshot in the interval
+	QuoteCurrency string            `protobuf:"bytes,5,opt,name=quoteCurrency,proto3" json:"quoteCurrency,omitempty"`
 }
 
-func (x *PotentialDeal) Reset() {
-	*x = PotentialDeal{}
+func (x *BalanceHistoryResponse) Reset() {
+	*x = BalanceHistoryResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pb_service_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -895,13 +1266,13 @@ func (x *PotentialDeal) Reset() {
 	}
 }
 
-func (x *PotentialDeal) String() string {
+func (x *BalanceHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PotentialDeal) ProtoMessage() {}
+func (*BalanceHistoryResponse) ProtoMessage() {}
 
-func (x *PotentialDeal) ProtoReflect() protoreflect.Message {
+func (x *BalanceHistoryResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pb_service_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -913,85 +1284,167 @@ func (x *PotentialDeal) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PotentialDeal.ProtoReflect.Descriptor instead.
-func (*PotentialDeal) Descriptor() ([]byte, []int) {
+// Deprecated: Use BalanceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*BalanceHistoryResponse) Descriptor() ([]byte, []int) {
 	return file_pb_service_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *PotentialDeal) GetSymbol() string {
+func (x *BalanceHistoryResponse) GetSymbols() []*BalanceHistory {
 	if x != nil {
-		return x.Symbol
+		return x.Symbols
 	}
-	return ""
+	return nil
 }
 
-func (x *PotentialDeal) GetActualRate() float32 {
+func (x *BalanceHistoryResponse) GetTotals() []*BalancePoint {
 	if x != nil {
-		return x.ActualRate
+		return x.Totals
 	}
-	return 0
+	return nil
 }
 
-func (x *PotentialDeal) GetPlanRate() float32 {
+func (x *BalanceHistoryResponse) GetQuoteCurrency() string {
 	if x != nil {
-		return x.PlanRate
+		return x.QuoteCurrency
 	}
-	return 0
+	return ""
 }
 
-func (x *PotentialDeal) GetLimit() float32 {
+type GetSymbolLimitsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId  int64    `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Symbols []string `protobuf:"bytes,3,rep,name=symbols,proto3" json:"symbols,omitempty"`
+}
+
+func (x *GetSymbolLimitsRequest) Reset() {
+	*x = GetSymbolLimitsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSymbolLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSymbolLimitsRequest) ProtoMessage() {}
+
+func (x *GetSymbolLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSymbolLimitsRequest.ProtoReflect.Descriptor instead.
+func (*GetSymbolLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetSymbolLimitsRequest) GetUserId() int64 {
 	if x != nil {
-		return x.Limit
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *PotentialDeal) GetTimeFrame() string {
+func (x *GetSymbolLimitsRequest) GetSymbols() []string {
 	if x != nil {
-		return x.TimeFrame
+		return x.Symbols
 	}
-	return ""
+	return nil
 }
 
-func (x *PotentialDeal) GetActualDelta() float32 {
+type SetSymbolLimitsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId int64          `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Limits []*SymbolLimit `protobuf:"bytes,3,rep,name=limits,proto3" json:"limits,omitempty"`
+}
+
+func (x *SetSymbolLimitsRequest) Reset() {
+	*x = SetSymbolLimitsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetSymbolLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSymbolLimitsRequest) ProtoMessage() {}
+
+func (x *SetSymbolLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSymbolLimitsRequest.ProtoReflect.Descriptor instead.
+func (*SetSymbolLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetSymbolLimitsRequest) GetUserId() int64 {
 	if x != nil {
-		return x.ActualDelta
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *PotentialDeal) GetPlanDelta() float32 {
+func (x *SetSymbolLimitsRequest) GetLimits() []*SymbolLimit {
 	if x != nil {
-		return x.PlanDelta
+		return x.Limits
 	}
-	return 0
+	return nil
 }
 
-type PotentialDealsResponse struct {
+type SymbolLimitsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Deal []*PotentialDeal `protobuf:"bytes,1,rep,name=deal,proto3" json:"deal,omitempty"`
+	Limits []*SymbolLimit `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits,omitempty"`
 }
 
-func (x *PotentialDealsResponse) Reset() {
-	*x = PotentialDealsResponse{}
+func (x *SymbolLimitsResponse) Reset() {
+	*x = SymbolLimitsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pb_service_proto_msgTypes[15]
+		mi := &file_pb_service_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PotentialDealsResponse) String() string {
+func (x *SymbolLimitsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PotentialDealsResponse) ProtoMessage() {}
+func (*SymbolLimitsResponse) ProtoMessage() {}
 
-func (x *PotentialDealsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_service_proto_msgTypes[15]
+func (x *SymbolLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1002,44 +1455,53 @@ func (x *PotentialDealsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PotentialDealsResponse.ProtoReflect.Descriptor instead.
-func (*PotentialDealsResponse) Descriptor() ([]byte, []int) {
-	return file_pb_service_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use SymbolLimitsResponse.ProtoReflect.Descriptor instead.
+func (*SymbolLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *PotentialDealsResponse) GetDeal() []*PotentialDeal {
+func (x *SymbolLimitsResponse) GetLimits() []*SymbolLimit {
 	if x != nil {
-		return x.Deal
+		return x.Limits
 	}
 	return nil
 }
 
-type Deal_DealPrediction struct {
+type DealsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Stop float32 `protobuf:"fixed32,1,opt,name=stop,proto3" json:"stop,omitempty"`
-	Max  float32 `protobuf:"fixed32,3,opt,name=max,proto3" json:"max,omitempty"`
+	UserId   int64                `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	All      bool                 `protobuf:"varint,3,opt,name=all,proto3" json:"all,omitempty"`
+	Symbols  []string             `protobuf:"bytes,5,rep,name=symbols,proto3" json:"symbols,omitempty"`
+	DateFrom *timestamp.Timestamp `protobuf:"bytes,7,opt,name=dateFrom,proto3" json:"dateFrom,omitempty"`
+	DateTo   *timestamp.Timestamp `protobuf:"bytes,9,opt,name=dateTo,proto3" json:"dateTo,omitempty"`
+	DealIds  []string             `protobuf:"bytes,11,rep,name=dealIds,proto3" json:"dealIds,omitempty"`
+	// paging and sorting are only used by GetActiveDeals
+	PageSize   int32                  `protobuf:"varint,13,opt,name=pageSize,proto3" json:"pageSize,omitempty"`  // 100 if not set, at most 1000
+	PageToken  string                 `protobuf:"bytes,15,opt,name=pageToken,proto3" json:"pageToken,omitempty"` // nextPageToken of the previous page, the sort must not change between pages
+	SortBy     DealsRequest_SortField `protobuf:"varint,17,opt,name=sortBy,proto3,enum=gandalf.DealsRequest_SortField" json:"sortBy,omitempty"`
+	Descending bool                   `protobuf:"varint,19,opt,name=descending,proto3" json:"descending,omitempty"`
 }
 
-func (x *Deal_DealPrediction) Reset() {
-	*x = Deal_DealPrediction{}
+func (x *DealsRequest) Reset() {
+	*x = DealsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pb_service_proto_msgTypes[16]
+		mi := &file_pb_service_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Deal_DealPrediction) String() string {
+func (x *DealsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Deal_DealPrediction) ProtoMessage() {}
+func (*DealsRequest) ProtoMessage() {}
 
-func (x *Deal_DealPrediction) ProtoReflect() protoreflect.Message {
-	mi := &file_pb_service_proto_msgTypes[16]
+func (x *DealsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1050,285 +1512,3385 @@ func (x *Deal_DealPrediction) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Deal_DealPrediction.ProtoReflect.Descriptor instead.
-func (*Deal_DealPrediction) Descriptor() ([]byte, []int) {
-	return file_pb_service_proto_rawDescGZIP(), []int{12, 0}
+// Deprecated: Use DealsRequest.ProtoReflect.Descriptor instead.
+func (*DealsRequest) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *Deal_DealPrediction) GetStop() float32 {
+func (x *DealsRequest) GetUserId() int64 {
 	if x != nil {
-		return x.Stop
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *Deal_DealPrediction) GetMax() float32 {
+func (x *DealsRequest) GetAll() bool {
 	if x != nil {
-		return x.Max
+		return x.All
 	}
-	return 0
+	return false
 }
 
-var File_pb_service_proto protoreflect.FileDescriptor
+func (x *DealsRequest) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
 
-var file_pb_service_proto_rawDesc = []byte{
-	0x0a, 0x10, 0x70, 0x62, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x12, 0x07, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x26, 0x0a, 0x0c,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
-	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73,
-	0x65, 0x72, 0x49, 0x64, 0x22, 0x0f, 0x0a, 0x0d, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa0, 0x01, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e,
-	0x67, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f,
-	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12,
-	0x3c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
-	0x24, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e,
-	0x67, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x39, 0x0a,
-	0x0d, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0d,
-	0x0a, 0x09, 0x50, 0x52, 0x45, 0x50, 0x41, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0a, 0x0a,
-	0x06, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x55, 0x53,
-	0x50, 0x45, 0x4e, 0x44, 0x45, 0x44, 0x10, 0x02, 0x22, 0x4a, 0x0a, 0x16, 0x54, 0x72, 0x61, 0x64,
-	0x69, 0x6e, 0x67, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x54, 0x72,
-	0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x07, 0x73, 0x79, 0x6d,
-	0x62, 0x6f, 0x6c, 0x73, 0x22, 0x3f, 0x0a, 0x0d, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a,
-	0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
-	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x22, 0x3f, 0x0a, 0x0d, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x42,
-	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x16,
-	0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x06,
-	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x4c, 0x0a, 0x16, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
-	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x32, 0x0a, 0x08, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d,
-	0x62, 0x6f, 0x6c, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x08, 0x62, 0x61, 0x6c, 0x61,
-	0x6e, 0x63, 0x65, 0x73, 0x22, 0x3b, 0x0a, 0x0b, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x4c, 0x69,
-	0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x6c,
-	0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
-	0x74, 0x22, 0x4a, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x4c, 0x69,
-	0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75,
-	0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65,
-	0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x22, 0x5e, 0x0a,
-	0x16, 0x53, 0x65, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12,
-	0x2c, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x14, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
-	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x44, 0x0a,
-	0x14, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e,
-	0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x6c, 0x69, 0x6d,
-	0x69, 0x74, 0x73, 0x22, 0xd8, 0x01, 0x0a, 0x0c, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03,
-	0x61, 0x6c, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12, 0x18,
-	0x0a, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x65,
-	0x46, 0x72, 0x6f, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x64, 0x61, 0x74, 0x65, 0x46, 0x72, 0x6f, 0x6d,
-	0x12, 0x32, 0x0a, 0x06, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x64, 0x61,
-	0x74, 0x65, 0x54, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x61, 0x6c, 0x49, 0x64, 0x73, 0x18,
-	0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x61, 0x6c, 0x49, 0x64, 0x73, 0x22, 0xec,
-	0x02, 0x0a, 0x04, 0x44, 0x65, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x61, 0x6c, 0x49,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x61, 0x6c, 0x49, 0x64, 0x12,
-	0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
-	0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x02, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x6d, 0x6f,
-	0x75, 0x6e, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28,
-	0x02, 0x52, 0x0e, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
-	0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
-	0x18, 0x0b, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x41, 0x6d, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x50, 0x65, 0x72, 0x63,
-	0x65, 0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0c, 0x64, 0x65, 0x6c, 0x74, 0x61,
-	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x64, 0x69,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61,
-	0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x50,
-	0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70, 0x72, 0x65, 0x64, 0x69,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x36, 0x0a, 0x0e, 0x44, 0x65, 0x61, 0x6c, 0x50, 0x72, 0x65,
-	0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6d,
-	0x61, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0x34, 0x0a,
-	0x0d, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23,
-	0x0a, 0x05, 0x64, 0x65, 0x61, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
-	0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x52, 0x05, 0x64, 0x65,
-	0x61, 0x6c, 0x73, 0x22, 0xd7, 0x01, 0x0a, 0x0d, 0x50, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61,
-	0x6c, 0x44, 0x65, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1e, 0x0a,
-	0x0a, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x52, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x02, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a,
-	0x08, 0x70, 0x6c, 0x61, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52,
-	0x08, 0x70, 0x6c, 0x61, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
-	0x69, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12,
-	0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a,
-	0x0b, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x0b, 0x20, 0x01,
-	0x28, 0x02, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12,
-	0x1c, 0x0a, 0x09, 0x70, 0x6c, 0x61, 0x6e, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x0d, 0x20, 0x01,
-	0x28, 0x02, 0x52, 0x09, 0x70, 0x6c, 0x61, 0x6e, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x22, 0x44, 0x0a,
-	0x16, 0x50, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x04, 0x64, 0x65, 0x61, 0x6c, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e,
-	0x50, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x61, 0x6c, 0x52, 0x04, 0x64,
-	0x65, 0x61, 0x6c, 0x32, 0xef, 0x06, 0x0a, 0x07, 0x47, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x12,
-	0x4b, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x79, 0x6d,
-	0x62, 0x6f, 0x6c, 0x73, 0x12, 0x15, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x61,
-	0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x79, 0x6d,
-	0x62, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x14,
-	0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x65,
-	0x70, 0x61, 0x72, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53,
-	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
-	0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x12, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x54, 0x72,
-	0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x61, 0x6e,
-	0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x11, 0x53, 0x79,
-	0x6d, 0x62, 0x6f, 0x6c, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x6f, 0x70, 0x12,
-	0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c,
-	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x46, 0x0a, 0x14, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67,
-	0x53, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x12, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c,
-	0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x13, 0x53, 0x79, 0x6d, 0x62, 0x6f,
-	0x6c, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x16,
-	0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b,
-	0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x42, 0x61, 0x6c, 0x61, 0x6e,
-	0x63, 0x65, 0x73, 0x12, 0x15, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x61, 0x6e,
-	0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x42, 0x61, 0x6c, 0x61, 0x6e,
-	0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0f, 0x47,
-	0x65, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x1f,
-	0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x79, 0x6d, 0x62,
-	0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1d, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
-	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a,
-	0x0a, 0x0f, 0x53, 0x65, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74,
-	0x73, 0x12, 0x1f, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x65, 0x74, 0x53,
-	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0e, 0x47, 0x65,
-	0x74, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x12, 0x15, 0x2e, 0x67,
-	0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x44, 0x65,
-	0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x11, 0x47,
-	0x65, 0x74, 0x50, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x61, 0x6c, 0x73,
-	0x12, 0x15, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c,
-	0x66, 0x2e, 0x50, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x61, 0x6c, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0a, 0x43, 0x6c, 0x6f, 0x73,
-	0x65, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x12, 0x15, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66,
-	0x2e, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
-	0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0e, 0x5a, 0x0c, 0x70, 0x62, 0x3b, 0x67, 0x61, 0x6e, 0x64,
-	0x61, 0x6c, 0x66, 0x50, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+func (x *DealsRequest) GetDateFrom() *timestamp.Timestamp {
+	if x != nil {
+		return x.DateFrom
+	}
+	return nil
 }
 
-var (
-	file_pb_service_proto_rawDescOnce sync.Once
-	file_pb_service_proto_rawDescData = file_pb_service_proto_rawDesc
-)
+func (x *DealsRequest) GetDateTo() *timestamp.Timestamp {
+	if x != nil {
+		return x.DateTo
+	}
+	return nil
+}
 
-func file_pb_service_proto_rawDescGZIP() []byte {
-	file_pb_service_proto_rawDescOnce.Do(func() {
-		file_pb_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_pb_service_proto_rawDescData)
-	})
-	return file_pb_service_proto_rawDescData
+func (x *DealsRequest) GetDealIds() []string {
+	if x != nil {
+		return x.DealIds
+	}
+	return nil
 }
 
-var file_pb_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_pb_service_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
-var file_pb_service_proto_goTypes = []interface{}{
-	(TradingSymbol_TradingStatus)(0), // 0: gandalf.TradingSymbol.TradingStatus
-	(*EmptyRequest)(nil),             // 1: gandalf.EmptyRequest
-	(*EmptyResponse)(nil),            // 2: gandalf.EmptyResponse
-	(*TradingSymbol)(nil),            // 3: gandalf.TradingSymbol
-	(*TradingSymbolsResponse)(nil),   // 4: gandalf.TradingSymbolsResponse
-	(*SymbolRequest)(nil),            // 5: gandalf.SymbolRequest
-	(*SymbolBalance)(nil),            // 6: gandalf.SymbolBalance
-	(*SymbolBalancesResponse)(nil),   // 7: gandalf.SymbolBalancesResponse
-	(*SymbolLimit)(nil),              // 8: gandalf.SymbolLimit
-	(*GetSymbolLimitsRequest)(nil),   // 9: gandalf.GetSymbolLimitsRequest
-	(*SetSymbolLimitsRequest)(nil),   // 10: gandalf.SetSymbolLimitsRequest
-	(*SymbolLimitsResponse)(nil),     // 11: gandalf.SymbolLimitsResponse
-	(*DealsRequest)(nil),             // 12: gandalf.DealsRequest
-	(*Deal)(nil),                     // 13: gandalf.Deal
-	(*DealsResponse)(nil),            // 14: gandalf.DealsResponse
-	(*PotentialDeal)(nil),            // 15: gandalf.PotentialDeal
-	(*PotentialDealsResponse)(nil),   // 16: gandalf.PotentialDealsResponse
-	(*Deal_DealPrediction)(nil),      // 17: gandalf.Deal.DealPrediction
-	(*timestamp.Timestamp)(nil),      // 18: google.protobuf.Timestamp
+func (x *DealsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
 }
-var file_pb_service_proto_depIdxs = []int32{
-	0,  // 0: gandalf.TradingSymbol.status:type_name -> gandalf.TradingSymbol.TradingStatus
-	3,  // 1: gandalf.TradingSymbolsResponse.symbols:type_name -> gandalf.TradingSymbol
-	6,  // 2: gandalf.SymbolBalancesResponse.balances:type_name -> gandalf.SymbolBalance
-	8,  // 3: gandalf.SetSymbolLimitsRequest.limits:type_name -> gandalf.SymbolLimit
-	8,  // 4: gandalf.SymbolLimitsResponse.limits:type_name -> gandalf.SymbolLimit
-	18, // 5: gandalf.DealsRequest.dateFrom:type_name -> google.protobuf.Timestamp
-	18, // 6: gandalf.DealsRequest.dateTo:type_name -> google.protobuf.Timestamp
-	18, // 7: gandalf.Deal.createdAt:type_name -> google.protobuf.Timestamp
-	17, // 8: gandalf.Deal.prediction:type_name -> gandalf.Deal.DealPrediction
-	13, // 9: gandalf.DealsResponse.deals:type_name -> gandalf.Deal
-	15, // 10: gandalf.PotentialDealsResponse.deal:type_name -> gandalf.PotentialDeal
-	1,  // 11: gandalf.Gandalf.GetTradingSymbols:input_type -> gandalf.EmptyRequest
-	5,  // 12: gandalf.Gandalf.SymbolTradingPrepare:input_type -> gandalf.SymbolRequest
-	5,  // 13: gandalf.Gandalf.SymbolTradingStart:input_type -> gandalf.SymbolRequest
-	5,  // 14: gandalf.Gandalf.SymbolTradingStop:input_type -> gandalf.SymbolRequest
-	5,  // 15: gandalf.Gandalf.SymbolTradingSuspend:input_type -> gandalf.SymbolRequest
-	5,  // 16: gandalf.Gandalf.SymbolTradingResume:input_type -> gandalf.SymbolRequest
-	1,  // 17: gandalf.Gandalf.GetSymbolBalances:input_type -> gandalf.EmptyRequest
-	9,  // 18: gandalf.Gandalf.GetSymbolLimits:input_type -> gandalf.GetSymbolLimitsRequest
-	10, // 19: gandalf.Gandalf.SetSymbolLimits:input_type -> gandalf.SetSymbolLimitsRequest
-	12, // 20: gandalf.Gandalf.GetActiveDeals:input_type -> gandalf.DealsRequest
-	12, // 21: gandalf.Gandalf.GetPotentialDeals:input_type -> gandalf.DealsRequest
-	12, // 22: gandalf.Gandalf.CloseDeals:input_type -> gandalf.DealsRequest
-	4,  // 23: gandalf.Gandalf.GetTradingSymbols:output_type -> gandalf.TradingSymbolsResponse
-	2,  // 24: gandalf.Gandalf.SymbolTradingPrepare:output_type -> gandalf.EmptyResponse
-	2,  // 25: gandalf.Gandalf.SymbolTradingStart:output_type -> gandalf.EmptyResponse
-	2,  // 26: gandalf.Gandalf.SymbolTradingStop:output_type -> gandalf.EmptyResponse
-	2,  // 27: gandalf.Gandalf.SymbolTradingSuspend:output_type -> gandalf.EmptyResponse
-	2,  // 28: gandalf.Gandalf.SymbolTradingResume:output_type -> gandalf.EmptyResponse
-	7,  // 29: gandalf.Gandalf.GetSymbolBalances:output_type -> gandalf.SymbolBalancesResponse
-	11, // 30: gandalf.Gandalf.GetSymbolLimits:output_type -> gandalf.SymbolLimitsResponse
-	2,  // 31: gandalf.Gandalf.SetSymbolLimits:output_type -> gandalf.EmptyResponse
-	14, // 32: gandalf.Gandalf.GetActiveDeals:output_type -> gandalf.DealsResponse
-	16, // 33: gandalf.Gandalf.GetPotentialDeals:output_type -> gandalf.PotentialDealsResponse
-	2,  // 34: gandalf.Gandalf.CloseDeals:output_type -> gandalf.EmptyResponse
-	23, // [23:35] is the sub-list for method output_type
-	11, // [11:23] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+
+func (x *DealsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
 }
 
-func init() { file_pb_service_proto_init() }
-func file_pb_service_proto_init() {
-	if File_pb_service_proto != nil {
-		return
+func (x *DealsRequest) GetSortBy() DealsRequest_SortField {
+	if x != nil {
+		return x.SortBy
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_pb_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EmptyRequest); i {
+	return DealsRequest_CREATED_AT
+}
+
+func (x *DealsRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+type Deal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DealId    string               `protobuf:"bytes,1,opt,name=dealId,proto3" json:"dealId,omitempty"` // possible format d-165738457656-adausdt or use Huobi's order id
+	Symbol    string               `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	// Deprecated: Do not use.
+	Amount float32 `protobuf:"fixed32,7,opt,name=amount,proto3" json:"amount,omitempty"` // use amountDecimal
+	// Deprecated: Do not use.
+	AmountCurrency float32 `protobuf:"fixed32,9,opt,name=amountCurrency,proto3" json:"amountCurrency,omitempty"` // use amountCurrencyDecimal
+	// Deprecated: Do not use.
+	DeltaAmount float32 `protobuf:"fixed32,11,opt,name=deltaAmount,proto3" json:"deltaAmount,omitempty"` // use deltaAmountDecimal
+	// Deprecated: Do not use.
+	DeltaPercent float32              `protobuf:"fixed32,13,opt,name=deltaPercent,proto3" json:"deltaPercent,omitempty"` // use deltaPercentDecimal
+	Prediction   *Deal_DealPrediction `protobuf:"bytes,15,opt,name=prediction,proto3" json:"prediction,omitempty"`
+	Paper        bool                 `protobuf:"varint,17,opt,name=paper,proto3" json:"paper,omitempty"`
+	// Deprecated: Do not use.
+	OpenPrice             float32 `protobuf:"fixed32,19,opt,name=openPrice,proto3" json:"openPrice,omitempty"` // use openPriceDecimal
+	AmountDecimal         string  `protobuf:"bytes,21,opt,name=amountDecimal,proto3" json:"amountDecimal,omitempty"`
+	AmountCurrencyDecimal string  `protobuf:"bytes,23,opt,name=amountCurrencyDecimal,proto3" json:"amountCurrencyDecimal,omitempty"`
+	DeltaAmountDecimal    string  `protobuf:"bytes,25,opt,name=deltaAmountDecimal,proto3" json:"deltaAmountDecimal,omitempty"`
+	DeltaPercentDecimal   string  `protobuf:"bytes,27,opt,name=deltaPercentDecimal,proto3" json:"deltaPercentDecimal,omitempty"`
+	OpenPriceDecimal      string  `protobuf:"bytes,29,opt,name=openPriceDecimal,proto3" json:"openPriceDecimal,omitempty"`
+	Timeframe             string  `protobuf:"bytes,31,opt,name=timeframe,proto3" json:"timeframe,omitempty"`
+	MaxFavorablePercent   string  `protobuf:"bytes,33,opt,name=maxFavorablePercent,proto3" json:"maxFavorablePercent,omitempty"` // highest delta percent seen while open
+	MaxAdversePercent     string  `protobuf:"bytes,35,opt,name=maxAdversePercent,proto3" json:"maxAdversePercent,omitempty"`     // lowest delta percent seen while open
+	FirstHit              string  `protobuf:"bytes,37,opt,name=firstHit,proto3" json:"firstHit,omitempty"`                       // stop or max, whichever the delta reached first, empty if none yet
+	Version               int64   `protobuf:"varint,39,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *Deal) Reset() {
+	*x = Deal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Deal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Deal) ProtoMessage() {}
+
+func (x *Deal) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Deal.ProtoReflect.Descriptor instead.
+func (*Deal) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Deal) GetDealId() string {
+	if x != nil {
+		return x.DealId
+	}
+	return ""
+}
+
+func (x *Deal) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Deal) GetCreatedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Deprecated: Do not use.
+func (x *Deal) GetAmount() float32 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// Deprecated: Do not use.
+func (x *Deal) GetAmountCurrency() float32 {
+	if x != nil {
+		return x.AmountCurrency
+	}
+	return 0
+}
+
+// Deprecated: Do not use.
+func (x *Deal) GetDeltaAmount() float32 {
+	if x != nil {
+		return x.DeltaAmount
+	}
+	return 0
+}
+
+// Deprecated: Do not use.
+func (x *Deal) GetDeltaPercent() float32 {
+	if x != nil {
+		return x.DeltaPercent
+	}
+	return 0
+}
+
+func (x *Deal) GetPrediction() *Deal_DealPrediction {
+	if x != nil {
+		return x.Prediction
+	}
+	return nil
+}
+
+func (x *Deal) GetPaper() bool {
+	if x != nil {
+		return x.Paper
+	}
+	return false
+}
+
+// Deprecated: Do not use.
+func (x *Deal) GetOpenPrice() float32 {
+	if x != nil {
+		return x.OpenPrice
+	}
+	return 0
+}
+
+func (x *Deal) GetAmountDecimal() string {
+	if x != nil {
+		return x.AmountDecimal
+	}
+	return ""
+}
+
+func (x *Deal) GetAmountCurrencyDecimal() string {
+	if x != nil {
+		return x.AmountCurrencyDecimal
+	}
+	return ""
+}
+
+func (x *Deal) GetDeltaAmountDecimal() string {
+	if x != nil {
+		return x.DeltaAmountDecimal
+	}
+	return ""
+}
+
+func (x *Deal) GetDeltaPercentDecimal() string {
+	if x != nil {
+		return x.DeltaPercentDecimal
+	}
+	return ""
+}
+
+func (x *Deal) GetOpenPriceDecimal() string {
+	if x != nil {
+		return x.OpenPriceDecimal
+	}
+	return ""
+}
+
+func (x *Deal) GetTimeframe() string {
+	if x != nil {
+		return x.Timeframe
+	}
+	return ""
+}
+
+func (x *Deal) GetMaxFavorablePercent() string {
+	if x != nil {
+		return x.MaxFavorablePercent
+	}
+	return ""
+}
+
+func (x *Deal) GetMaxAdversePercent() string {
+	if x != nil {
+		return x.MaxAdversePercent
+	}
+	return ""
+}
+
+func (x *Deal) GetFirstHit() string {
+	if x != nil {
+		return x.FirstHit
+	}
+	return ""
+}
+
+func (x *Deal) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DealsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deals         []*Deal `protobuf:"bytes,1,rep,name=deals,proto3" json:"deals,omitempty"`
+	NextPageToken string  `protobuf:"bytes,3,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // empty on the last page
+}
+
+func (x *DealsResponse) Reset() {
+	*x = DealsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DealsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DealsResponse) ProtoMessage() {}
+
+func (x *DealsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DealsResponse.ProtoReflect.Descriptor instead.
+func (*DealsResponse) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DealsResponse) GetDeals() []*Deal {
+	if x != nil {
+		return x.Deals
+	}
+	return nil
+}
+
+func (x *DealsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DealResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deal *Deal `protobuf:"bytes,1,opt,name=deal,proto3" json:"deal,omitempty"`
+}
+
+func (x *DealResponse) Reset() {
+	*x = DealResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DealResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DealResponse) ProtoMessage() {}
+
+func (x *DealResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DealResponse.ProtoReflect.Descriptor instead.
+func (*DealResponse) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DealResponse) GetDeal() *Deal {
+	if x != nil {
+		return x.Deal
+	}
+	return nil
+}
+
+type OpenPaperDealRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId int64  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Symbol string `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	// Deprecated: Do not use.
+	AmountCurrency        float32              `protobuf:"fixed32,5,opt,name=amountCurrency,proto3" json:"amountCurrency,omitempty"` // use amountCurrencyDecimal
+	Prediction            *Deal_DealPrediction `protobuf:"bytes,7,opt,name=prediction,proto3" json:"prediction,omitempty"`
+	AmountCurrencyDecimal string               `protobuf:"bytes,9,opt,name=amountCurrencyDecimal,proto3" json:"amountCurrencyDecimal,omitempty"`
+}
+
+func (x *OpenPaperDealRequest) Reset() {
+	*x = OpenPaperDealRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpenPaperDealRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenPaperDealRequest) ProtoMessage() {}
+
+func (x *OpenPaperDealRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenPaperDealRequest.ProtoReflect.Descriptor instead.
+func (*OpenPaperDealRequest) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *OpenPaperDealRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *OpenPaperDealRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+// Deprecated: Do not use.
+func (x *OpenPaperDealRequest) GetAmountCurrency() float32 {
+	if x != nil {
+		return x.AmountCurrency
+	}
+	return 0
+}
+
+func (x *OpenPaperDealRequest) GetPrediction() *Deal_DealPrediction {
+	if x != nil {
+		return x.Prediction
+	}
+	return nil
+}
+
+func (x *OpenPaperDealRequest) GetAmountCurrencyDecimal() string {
+	if x != nil {
+		return x.AmountCurrencyDecimal
+	}
+	return ""
+}
+
+type PotentialDeal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol      string  `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	ActualRate  float32 `protobuf:"fixed32,3,opt,name=actualRate,proto3" json:"actualRate,omitempty"`
+	PlanRate    float32 `protobuf:"fixed32,5,opt,name=planRate,proto3" json:"planRate,omitempty"`
+	Limit       float32 `protobuf:"fixed32,7,opt,name=limit,proto3" json:"limit,omitempty"`
+	TimeFrame   string  `protobuf:"bytes,9,opt,name=timeFrame,proto3" json:"timeFrame,omitempty"`
+	ActualDelta float32 `protobuf:"fixed32,11,opt,name=actualDelta,proto3" json:"actualDelta,omitempty"`
+	PlanDelta   float32 `protobuf:"fixed32,13,opt,name=planDelta,proto3" json:"planDelta,omitempty"`
+}
+
+func (x *PotentialDeal) Reset() {
+	*x = PotentialDeal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PotentialDeal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PotentialDeal) ProtoMessage() {}
+
+func (x *PotentialDeal) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PotentialDeal.ProtoReflect.Descriptor instead.
+func (*PotentialDeal) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PotentialDeal) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *PotentialDeal) GetActualRate() float32 {
+	if x != nil {
+		return x.ActualRate
+	}
+	return 0
+}
+
+func (x *PotentialDeal) GetPlanRate() float32 {
+	if x != nil {
+		return x.PlanRate
+	}
+	return 0
+}
+
+func (x *PotentialDeal) GetLimit() float32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *PotentialDeal) GetTimeFrame() string {
+	if x != nil {
+		return x.TimeFrame
+	}
+	return ""
+}
+
+func (x *PotentialDeal) GetActualDelta() float32 {
+	if x != nil {
+		return x.ActualDelta
+	}
+	return 0
+}
+
+func (x *PotentialDeal) GetPlanDelta() float32 {
+	if x != nil {
+		return x.PlanDelta
+	}
+	return 0
+}
+
+type PotentialDealsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deal []*PotentialDeal `protobuf:"bytes,1,rep,name=deal,proto3" json:"deal,omitempty"`
+}
+
+func (x *PotentialDealsResponse) Reset() {
+	*x = PotentialDealsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PotentialDealsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PotentialDealsResponse) ProtoMessage() {}
+
+func (x *PotentialDealsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PotentialDealsResponse.ProtoReflect.Descriptor instead.
+func (*PotentialDealsResponse) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PotentialDealsResponse) GetDeal() []*PotentialDeal {
+	if x != nil {
+		return x.Deal
+	}
+	return nil
+}
+
+type NotificationChannel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sink   string `protobuf:"bytes,1,opt,name=sink,proto3" json:"sink,omitempty"`     // webhook, telegram or log
+	Target string `protobuf:"bytes,3,opt,name=target,proto3" json:"target,omitempty"` // webhook url or telegram chat id, unused by log
+}
+
+func (x *NotificationChannel) Reset() {
+	*x = NotificationChannel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NotificationChannel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotificationChannel) ProtoMessage() {}
+
+func (x *NotificationChannel) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotificationChannel.ProtoReflect.Descriptor instead.
+func (*NotificationChannel) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *NotificationChannel) GetSink() string {
+	if x != nil {
+		return x.Sink
+	}
+	return ""
+}
+
+func (x *NotificationChannel) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+type Subscription struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Events   []string               `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`   // deal_opened, deal_closed, status_changed, limit_breached, approval, error; all if empty
+	Symbols  []string               `protobuf:"bytes,3,rep,name=symbols,proto3" json:"symbols,omitempty"` // all if empty
+	Channels []*NotificationChannel `protobuf:"bytes,5,rep,name=channels,proto3" json:"channels,omitempty"`
+}
+
+func (x *Subscription) Reset() {
+	*x = Subscription{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Subscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subscription) ProtoMessage() {}
+
+func (x *Subscription) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subscription.ProtoReflect.Descriptor instead.
+func (*Subscription) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Subscription) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *Subscription) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+func (x *Subscription) GetChannels() []*NotificationChannel {
+	if x != nil {
+		return x.Channels
+	}
+	return nil
+}
+
+type SubscriptionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Subscription *Subscription `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+}
+
+func (x *SubscriptionResponse) Reset() {
+	*x = SubscriptionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionResponse) ProtoMessage() {}
+
+func (x *SubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*SubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SubscriptionResponse) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type SetSubscriptionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId       int64         `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Subscription *Subscription `protobuf:"bytes,3,opt,name=subscription,proto3" json:"subscription,omitempty"`
+}
+
+func (x *SetSubscriptionRequest) Reset() {
+	*x = SetSubscriptionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSubscriptionRequest) ProtoMessage() {}
+
+func (x *SetSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*SetSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SetSubscriptionRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetSubscriptionRequest) GetSubscription() *Subscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type EmergencyHaltRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId     int64  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	CloseDeals bool   `protobuf:"varint,3,opt,name=closeDeals,proto3" json:"closeDeals,omitempty"`
+	Reason     string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *EmergencyHaltRequest) Reset() {
+	*x = EmergencyHaltRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EmergencyHaltRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmergencyHaltRequest) ProtoMessage() {}
+
+func (x *EmergencyHaltRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmergencyHaltRequest.ProtoReflect.Descriptor instead.
+func (*EmergencyHaltRequest) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *EmergencyHaltRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *EmergencyHaltRequest) GetCloseDeals() bool {
+	if x != nil {
+		return x.CloseDeals
+	}
+	return false
+}
+
+func (x *EmergencyHaltRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type Schedule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ScheduleId string                      `protobuf:"bytes,1,opt,name=scheduleId,proto3" json:"scheduleId,omitempty"`
+	Symbol     string                      `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Status     TradingSymbol_TradingStatus `protobuf:"varint,5,opt,name=status,proto3,enum=gandalf.TradingSymbol_TradingStatus" json:"status,omitempty"` // ACTIVE or SUSPENDED
+	Cron       string                      `protobuf:"bytes,7,opt,name=cron,proto3" json:"cron,omitempty"`                                               // minute hour day-of-month month day-of-week in UTC, empty for a single change
+	NextRunAt  *timestamp.Timestamp        `protobuf:"bytes,9,opt,name=nextRunAt,proto3" json:"nextRunAt,omitempty"`                                     // empty when a single change is done
+	LastRunAt  *timestamp.Timestamp        `protobuf:"bytes,11,opt,name=lastRunAt,proto3" json:"lastRunAt,omitempty"`
+	LastError  string                      `protobuf:"bytes,13,opt,name=lastError,proto3" json:"lastError,omitempty"`
+	CreatedBy  int64                       `protobuf:"varint,15,opt,name=createdBy,proto3" json:"createdBy,omitempty"`
+}
+
+func (x *Schedule) Reset() {
+	*x = Schedule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Schedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Schedule) ProtoMessage() {}
+
+func (x *Schedule) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Schedule.ProtoReflect.Descriptor instead.
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Schedule) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+func (x *Schedule) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Schedule) GetStatus() TradingSymbol_TradingStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TradingSymbol_PREPARING
+}
+
+func (x *Schedule) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+func (x *Schedule) GetNextRunAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return nil
+}
+
+func (x *Schedule) GetLastRunAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.LastRunAt
+	}
+	return nil
+}
+
+func (x *Schedule) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *Schedule) GetCreatedBy() int64 {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return 0
+}
+
+type ScheduleStatusChangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId int64                       `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Symbol string                      `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Status TradingSymbol_TradingStatus `protobuf:"varint,5,opt,name=status,proto3,enum=gandalf.TradingSymbol_TradingStatus" json:"status,omitempty"` // ACTIVE or SUSPENDED
+	RunAt  *timestamp.Timestamp        `protobuf:"bytes,7,opt,name=runAt,proto3" json:"runAt,omitempty"`                                             // either runAt or cron must be set
+	Cron   string                      `protobuf:"bytes,9,opt,name=cron,proto3" json:"cron,omitempty"`
+}
+
+func (x *ScheduleStatusChangeRequest) Reset() {
+	*x = ScheduleStatusChangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleStatusChangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleStatusChangeRequest) ProtoMessage() {}
+
+func (x *ScheduleStatusChangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleStatusChangeRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleStatusChangeRequest) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ScheduleStatusChangeRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ScheduleStatusChangeRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ScheduleStatusChangeRequest) GetStatus() TradingSymbol_TradingStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TradingSymbol_PREPARING
+}
+
+func (x *ScheduleStatusChangeRequest) GetRunAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.RunAt
+	}
+	return nil
+}
+
+func (x *ScheduleStatusChangeRequest) GetCron() string {
+	if x != nil {
+		return x.Cron
+	}
+	return ""
+}
+
+type ScheduleResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Schedule *Schedule `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+}
+
+func (x *ScheduleResponse) Reset() {
+	*x = ScheduleResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleResponse) ProtoMessage() {}
+
+func (x *ScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleResponse.ProtoReflect.Descriptor instead.
+func (*ScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ScheduleResponse) GetSchedule() *Schedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+type SchedulesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Schedules []*Schedule `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+}
+
+func (x *SchedulesResponse) Reset() {
+	*x = SchedulesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchedulesResponse) ProtoMessage() {}
+
+func (x *SchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchedulesResponse.ProtoReflect.Descriptor instead.
+func (*SchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SchedulesResponse) GetSchedules() []*Schedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type CancelScheduleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId     int64  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	ScheduleId string `protobuf:"bytes,3,opt,name=scheduleId,proto3" json:"scheduleId,omitempty"`
+}
+
+func (x *CancelScheduleRequest) Reset() {
+	*x = CancelScheduleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CancelScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduleRequest) ProtoMessage() {}
+
+func (x *CancelScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduleRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CancelScheduleRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CancelScheduleRequest) GetScheduleId() string {
+	if x != nil {
+		return x.ScheduleId
+	}
+	return ""
+}
+
+type GetSymbolConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId  int64  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Symbol  string `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	History bool   `protobuf:"varint,5,opt,name=history,proto3" json:"history,omitempty"`
+}
+
+func (x *GetSymbolConfigRequest) Reset() {
+	*x = GetSymbolConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSymbolConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSymbolConfigRequest) ProtoMessage() {}
+
+func (x *GetSymbolConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSymbolConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetSymbolConfigRequest) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetSymbolConfigRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetSymbolConfigRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetSymbolConfigRequest) GetHistory() bool {
+	if x != nil {
+		return x.History
+	}
+	return false
+}
+
+type SetSymbolConfigRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId          int64         `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Symbol          string        `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Config          *SymbolConfig `protobuf:"bytes,5,opt,name=config,proto3" json:"config,omitempty"`                    // empty fields keep the current values
+	ExpectedVersion int32         `protobuf:"varint,7,opt,name=expectedVersion,proto3" json:"expectedVersion,omitempty"` // the change fails if the current version differs, unchecked if zero
+}
+
+func (x *SetSymbolConfigRequest) Reset() {
+	*x = SetSymbolConfigRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SetSymbolConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetSymbolConfigRequest) ProtoMessage() {}
+
+func (x *SetSymbolConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetSymbolConfigRequest.ProtoReflect.Descriptor instead.
+func (*SetSymbolConfigRequest) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SetSymbolConfigRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SetSymbolConfigRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *SetSymbolConfigRequest) GetConfig() *SymbolConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *SetSymbolConfigRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type SymbolConfigResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol  string          `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Config  *SymbolConfig   `protobuf:"bytes,3,opt,name=config,proto3" json:"config,omitempty"`
+	History []*SymbolConfig `protobuf:"bytes,5,rep,name=history,proto3" json:"history,omitempty"` // previous versions, newest first, only if requested
+}
+
+func (x *SymbolConfigResponse) Reset() {
+	*x = SymbolConfigResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SymbolConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SymbolConfigResponse) ProtoMessage() {}
+
+func (x *SymbolConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SymbolConfigResponse.ProtoReflect.Descriptor instead.
+func (*SymbolConfigResponse) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SymbolConfigResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *SymbolConfigResponse) GetConfig() *SymbolConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+func (x *SymbolConfigResponse) GetHistory() []*SymbolConfig {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+type PredictionStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId   int64                `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Symbols  []string             `protobuf:"bytes,3,rep,name=symbols,proto3" json:"symbols,omitempty"`   // all if empty
+	DateFrom *timestamp.Timestamp `protobuf:"bytes,5,opt,name=dateFrom,proto3" json:"dateFrom,omitempty"` // of closing the deals
+	DateTo   *timestamp.Timestamp `protobuf:"bytes,7,opt,name=dateTo,proto3" json:"dateTo,omitempty"`
+}
+
+func (x *PredictionStatsRequest) Reset() {
+	*x = PredictionStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PredictionStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictionStatsRequest) ProtoMessage() {}
+
+func (x *PredictionStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictionStatsRequest.ProtoReflect.Descriptor instead.
+func (*PredictionStatsRequest) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PredictionStatsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PredictionStatsRequest) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+func (x *PredictionStatsRequest) GetDateFrom() *timestamp.Timestamp {
+	if x != nil {
+		return x.DateFrom
+	}
+	return nil
+}
+
+func (x *PredictionStatsRequest) GetDateTo() *timestamp.Timestamp {
+	if x != nil {
+		return x.DateTo
+	}
+	return nil
+}
+
+type PredictionStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol                 string  `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Timeframe              string  `protobuf:"bytes,3,opt,name=timeframe,proto3" json:"timeframe,omitempty"`
+	Deals                  int32   `protobuf:"varint,5,opt,name=deals,proto3" json:"deals,omitempty"`
+	StopHitFirst           int32   `protobuf:"varint,7,opt,name=stopHitFirst,proto3" json:"stopHitFirst,omitempty"`
+	MaxHitFirst            int32   `protobuf:"varint,9,opt,name=maxHitFirst,proto3" json:"maxHitFirst,omitempty"`
+	NoneHit                int32   `protobuf:"varint,11,opt,name=noneHit,proto3" json:"noneHit,omitempty"`
+	StopHitRatio           float64 `protobuf:"fixed64,13,opt,name=stopHitRatio,proto3" json:"stopHitRatio,omitempty"` // share of deals whose stop was reached at any time
+	MaxHitRatio            float64 `protobuf:"fixed64,15,opt,name=maxHitRatio,proto3" json:"maxHitRatio,omitempty"`   // share of deals whose max was reached at any time
+	AvgPredictedStop       float64 `protobuf:"fixed64,17,opt,name=avgPredictedStop,proto3" json:"avgPredictedStop,omitempty"`
+	AvgPredictedMax        float64 `protobuf:"fixed64,19,opt,name=avgPredictedMax,proto3" json:"avgPredictedMax,omitempty"`
+	AvgMaxAdversePercent   float64 `protobuf:"fixed64,21,opt,name=avgMaxAdversePercent,proto3" json:"avgMaxAdversePercent,omitempty"`
+	AvgMaxFavorablePercent float64 `protobuf:"fixed64,23,opt,name=avgMaxFavorablePercent,proto3" json:"avgMaxFavorablePercent,omitempty"`
+	AvgResultPercent       float64 `protobuf:"fixed64,25,opt,name=avgResultPercent,proto3" json:"avgResultPercent,omitempty"`
+}
+
+func (x *PredictionStats) Reset() {
+	*x = PredictionStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PredictionStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictionStats) ProtoMessage() {}
+
+func (x *PredictionStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictionStats.ProtoReflect.Descriptor instead.
+func (*PredictionStats) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PredictionStats) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *PredictionStats) GetTimeframe() string {
+	if x != nil {
+		return x.Timeframe
+	}
+	return ""
+}
+
+func (x *PredictionStats) GetDeals() int32 {
+	if x != nil {
+		return x.Deals
+	}
+	return 0
+}
+
+func (x *PredictionStats) GetStopHitFirst() int32 {
+	if x != nil {
+		return x.StopHitFirst
+	}
+	return 0
+}
+
+func (x *PredictionStats) GetMaxHitFirst() int32 {
+	if x != nil {
+		return x.MaxHitFirst
+	}
+	return 0
+}
+
+func (x *PredictionStats) GetNoneHit() int32 {
+	if x != nil {
+		return x.NoneHit
+	}
+	return 0
+}
+
+func (x *PredictionStats) GetStopHitRatio() float64 {
+	if x != nil {
+		return x.StopHitRatio
+	}
+	return 0
+}
+
+func (x *PredictionStats) GetMaxHitRatio() float64 {
+	if x != nil {
+		return x.MaxHitRatio
+	}
+	return 0
+}
+
+func (x *PredictionStats) GetAvgPredictedStop() float64 {
+	if x != nil {
+		return x.AvgPredictedStop
+	}
+	return 0
+}
+
+func (x *PredictionStats) GetAvgPredictedMax() float64 {
+	if x != nil {
+		return x.AvgPredictedMax
+	}
+	return 0
+}
+
+func (x *PredictionStats) GetAvgMaxAdversePercent() float64 {
+	if x != nil {
+		return x.AvgMaxAdversePercent
+	}
+	return 0
+}
+
+func (x *PredictionStats) GetAvgMaxFavorablePercent() float64 {
+	if x != nil {
+		return x.AvgMaxFavorablePercent
+	}
+	return 0
+}
+
+func (x *PredictionStats) GetAvgResultPercent() float64 {
+	if x != nil {
+		return x.AvgResultPercent
+	}
+	return 0
+}
+
+type PredictionStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stats []*PredictionStats `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+}
+
+func (x *PredictionStatsResponse) Reset() {
+	*x = PredictionStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PredictionStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictionStatsResponse) ProtoMessage() {}
+
+func (x *PredictionStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictionStatsResponse.ProtoReflect.Descriptor instead.
+func (*PredictionStatsResponse) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PredictionStatsResponse) GetStats() []*PredictionStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type ExportDealsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId   int64                     `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	DateFrom *timestamp.Timestamp      `protobuf:"bytes,3,opt,name=dateFrom,proto3" json:"dateFrom,omitempty"` // open deals created, closed deals closed and balances snapshotted within the range
+	DateTo   *timestamp.Timestamp      `protobuf:"bytes,5,opt,name=dateTo,proto3" json:"dateTo,omitempty"`     // now if not set
+	Symbols  []string                  `protobuf:"bytes,7,rep,name=symbols,proto3" json:"symbols,omitempty"`   // all if empty
+	Format   ExportDealsRequest_Format `protobuf:"varint,9,opt,name=format,proto3,enum=gandalf.ExportDealsRequest_Format" json:"format,omitempty"`
+	// record, id, symbol, status, time, closed_at, amount, amount_currency, delta_amount, delta_percent,
+	// open_price, stop, max, timeframe, paper, balance, quote_value, quote; all in this order if empty
+	Columns  []string `protobuf:"bytes,11,rep,name=columns,proto3" json:"columns,omitempty"`
+	Timezone string   `protobuf:"bytes,13,opt,name=timezone,proto3" json:"timezone,omitempty"` // IANA name like Europe/Berlin of the times, UTC if empty
+}
+
+func (x *ExportDealsRequest) Reset() {
+	*x = ExportDealsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportDealsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportDealsRequest) ProtoMessage() {}
+
+func (x *ExportDealsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportDealsRequest.ProtoReflect.Descriptor instead.
+func (*ExportDealsRequest) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ExportDealsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ExportDealsRequest) GetDateFrom() *timestamp.Timestamp {
+	if x != nil {
+		return x.DateFrom
+	}
+	return nil
+}
+
+func (x *ExportDealsRequest) GetDateTo() *timestamp.Timestamp {
+	if x != nil {
+		return x.DateTo
+	}
+	return nil
+}
+
+func (x *ExportDealsRequest) GetSymbols() []string {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+func (x *ExportDealsRequest) GetFormat() ExportDealsRequest_Format {
+	if x != nil {
+		return x.Format
+	}
+	return ExportDealsRequest_CSV
+}
+
+func (x *ExportDealsRequest) GetColumns() []string {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *ExportDealsRequest) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+// ExportChunk is a part of the exported file, the chunks are to be written in the order they are received.
+type ExportChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data        []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	ContentType string `protobuf:"bytes,3,opt,name=contentType,proto3" json:"contentType,omitempty"` // only in the first chunk
+	FileName    string `protobuf:"bytes,5,opt,name=fileName,proto3" json:"fileName,omitempty"`       // only in the first chunk
+}
+
+func (x *ExportChunk) Reset() {
+	*x = ExportChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportChunk) ProtoMessage() {}
+
+func (x *ExportChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportChunk.ProtoReflect.Descriptor instead.
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ExportChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ExportChunk) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ExportChunk) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+type ApprovalEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time    *timestamp.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	UserId  int64                `protobuf:"varint,3,opt,name=userId,proto3" json:"userId,omitempty"`  // 0 for steps of the service, e.g. expiry
+	Action  string               `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`   // requested, approved, rejected, expired, executed or failed
+	Message string               `protobuf:"bytes,7,opt,name=message,proto3" json:"message,omitempty"` // reason of a rejection or error of a failed call
+}
+
+func (x *ApprovalEvent) Reset() {
+	*x = ApprovalEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApprovalEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalEvent) ProtoMessage() {}
+
+func (x *ApprovalEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalEvent.ProtoReflect.Descriptor instead.
+func (*ApprovalEvent) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ApprovalEvent) GetTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ApprovalEvent) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ApprovalEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ApprovalEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Approval is a call of an rpc that needs a second operator. It is pending
+// until another operator approves or anyone rejects it, or until it expires.
+type Approval struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ApprovalId  string               `protobuf:"bytes,1,opt,name=approvalId,proto3" json:"approvalId,omitempty"`
+	Method      string               `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	Request     string               `protobuf:"bytes,5,opt,name=request,proto3" json:"request,omitempty"` // request of the call as JSON
+	RequestedBy int64                `protobuf:"varint,7,opt,name=requestedBy,proto3" json:"requestedBy,omitempty"`
+	Status      string               `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"` // pending, rejected, expired, executed or failed
+	CreatedAt   *timestamp.Timestamp `protobuf:"bytes,11,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	ExpiresAt   *timestamp.Timestamp `protobuf:"bytes,13,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	Events      []*ApprovalEvent     `protobuf:"bytes,15,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *Approval) Reset() {
+	*x = Approval{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Approval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Approval) ProtoMessage() {}
+
+func (x *Approval) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Approval.ProtoReflect.Descriptor instead.
+func (*Approval) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *Approval) GetApprovalId() string {
+	if x != nil {
+		return x.ApprovalId
+	}
+	return ""
+}
+
+func (x *Approval) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *Approval) GetRequest() string {
+	if x != nil {
+		return x.Request
+	}
+	return ""
+}
+
+func (x *Approval) GetRequestedBy() int64 {
+	if x != nil {
+		return x.RequestedBy
+	}
+	return 0
+}
+
+func (x *Approval) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Approval) GetCreatedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Approval) GetExpiresAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Approval) GetEvents() []*ApprovalEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type ApprovalRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId     int64  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	ApprovalId string `protobuf:"bytes,3,opt,name=approvalId,proto3" json:"approvalId,omitempty"`
+	Reason     string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"` // only used by Reject
+}
+
+func (x *ApprovalRequest) Reset() {
+	*x = ApprovalRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApprovalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalRequest) ProtoMessage() {}
+
+func (x *ApprovalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalRequest.ProtoReflect.Descriptor instead.
+func (*ApprovalRequest) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ApprovalRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ApprovalRequest) GetApprovalId() string {
+	if x != nil {
+		return x.ApprovalId
+	}
+	return ""
+}
+
+func (x *ApprovalRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ApprovalResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Approval *Approval `protobuf:"bytes,1,opt,name=approval,proto3" json:"approval,omitempty"`
+}
+
+func (x *ApprovalResponse) Reset() {
+	*x = ApprovalResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApprovalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalResponse) ProtoMessage() {}
+
+func (x *ApprovalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalResponse.ProtoReflect.Descriptor instead.
+func (*ApprovalResponse) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ApprovalResponse) GetApproval() *Approval {
+	if x != nil {
+		return x.Approval
+	}
+	return nil
+}
+
+type ApprovalsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Approvals []*Approval `protobuf:"bytes,1,rep,name=approvals,proto3" json:"approvals,omitempty"`
+}
+
+func (x *ApprovalsResponse) Reset() {
+	*x = ApprovalsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApprovalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalsResponse) ProtoMessage() {}
+
+func (x *ApprovalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalsResponse.ProtoReflect.Descriptor instead.
+func (*ApprovalsResponse) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ApprovalsResponse) GetApprovals() []*Approval {
+	if x != nil {
+		return x.Approvals
+	}
+	return nil
+}
+
+type Deal_DealPrediction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stop float32 `protobuf:"fixed32,1,opt,name=stop,proto3" json:"stop,omitempty"`
+	Max  float32 `protobuf:"fixed32,3,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *Deal_DealPrediction) Reset() {
+	*x = Deal_DealPrediction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pb_service_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Deal_DealPrediction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Deal_DealPrediction) ProtoMessage() {}
+
+func (x *Deal_DealPrediction) ProtoReflect() protoreflect.Message {
+	mi := &file_pb_service_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Deal_DealPrediction.ProtoReflect.Descriptor instead.
+func (*Deal_DealPrediction) Descriptor() ([]byte, []int) {
+	return file_pb_service_proto_rawDescGZIP(), []int{19, 0}
+}
+
+func (x *Deal_DealPrediction) GetStop() float32 {
+	if x != nil {
+		return x.Stop
+	}
+	return 0
+}
+
+func (x *Deal_DealPrediction) GetMax() float32 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+var File_pb_service_proto protoreflect.FileDescriptor
+
+var file_pb_service_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x70, 0x62, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x07, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x1a, 0x1f, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x26, 0x0a, 0x0c,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x22, 0x0f, 0x0a, 0x0d, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xa5, 0x02, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e,
+	0x67, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12,
+	0x3c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x24, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e,
+	0x67, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x70, 0x61, 0x70, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x70, 0x61,
+	0x70, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x75, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x52, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x75, 0x73, 0x70,
+	0x65, 0x6e, 0x64, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x06, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x61, 0x6e, 0x64,
+	0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x39, 0x0a, 0x0d, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x0d, 0x0a, 0x09, 0x50, 0x52, 0x45, 0x50, 0x41, 0x52, 0x49, 0x4e, 0x47,
+	0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x0d,
+	0x0a, 0x09, 0x53, 0x55, 0x53, 0x50, 0x45, 0x4e, 0x44, 0x45, 0x44, 0x10, 0x02, 0x22, 0xdc, 0x02,
+	0x0a, 0x0c, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x11, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x44,
+	0x65, 0x6c, 0x74, 0x61, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x11, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x50, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x74, 0x6f, 0x70, 0x50, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x70, 0x50,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x50, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x50,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x12, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e,
+	0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x74, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x53,
+	0x69, 0x7a, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x42,
+	0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x42, 0x79, 0x12, 0x38, 0x0a, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18,
+	0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x69, 0x0a, 0x15,
+	0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x67,
+	0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x88, 0x01, 0x0a, 0x16, 0x54, 0x72, 0x61, 0x64,
+	0x69, 0x6e, 0x67, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x30, 0x0a, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x54, 0x72,
+	0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x07, 0x73, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x68, 0x61, 0x6c, 0x74, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x0d,
+	0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x22, 0x84, 0x01, 0x0a, 0x0d, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x70, 0x65, 0x72, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x70, 0x61, 0x70, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x06, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x61, 0x6e,
+	0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0x55, 0x0a, 0x15, 0x53, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x71, 0x75,
+	0x6f, 0x74, 0x65, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79,
+	0x22, 0xbb, 0x01, 0x0a, 0x0d, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x42, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1a, 0x0a, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x42, 0x02, 0x18, 0x01, 0x52, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0a, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0a,
+	0x71, 0x75, 0x6f, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c,
+	0x12, 0x2c, 0x0a, 0x11, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x65,
+	0x63, 0x69, 0x6d, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x71, 0x75, 0x6f,
+	0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x22, 0xc0,
+	0x02, 0x0a, 0x16, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x62, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x61,
+	0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x42, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x52, 0x08, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x3c, 0x0a,
+	0x0d, 0x70, 0x61, 0x70, 0x65, 0x72, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x0d, 0x70, 0x61,
+	0x70, 0x65, 0x72, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x71,
+	0x75, 0x6f, 0x74, 0x65, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x12, 0x18, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x02,
+	0x42, 0x02, 0x18, 0x01, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x22, 0x0a, 0x0a, 0x70,
+	0x61, 0x70, 0x65, 0x72, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x02, 0x42,
+	0x02, 0x18, 0x01, 0x52, 0x0a, 0x70, 0x61, 0x70, 0x65, 0x72, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12,
+	0x22, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x44, 0x65, 0x63, 0x69,
+	0x6d, 0x61, 0x6c, 0x12, 0x2c, 0x0a, 0x11, 0x70, 0x61, 0x70, 0x65, 0x72, 0x54, 0x6f, 0x74, 0x61,
+	0x6c, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11,
+	0x70, 0x61, 0x70, 0x65, 0x72, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61,
+	0x6c, 0x22, 0x93, 0x01, 0x0a, 0x0b, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x18, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x42, 0x02, 0x18, 0x01, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x44, 0x65, 0x63, 0x69,
+	0x6d, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x12, 0x2e, 0x0a, 0x12, 0x6d, 0x61, 0x78, 0x44, 0x72,
+	0x61, 0x77, 0x64, 0x6f, 0x77, 0x6e, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x44, 0x72, 0x61, 0x77, 0x64, 0x6f, 0x77, 0x6e,
+	0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x22, 0xdb, 0x02, 0x0a, 0x15, 0x42, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x73, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x08, 0x64, 0x61, 0x74, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x32, 0x0a, 0x06, 0x64,
+	0x61, 0x74, 0x65, 0x54, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x12,
+	0x43, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x27, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x42, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65,
+	0x12, 0x24, 0x0a, 0x0d, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x43, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x1d, 0x0a, 0x08, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x4f, 0x55, 0x52, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03,
+	0x44, 0x41, 0x59, 0x10, 0x01, 0x22, 0xfc, 0x01, 0x0a, 0x0c, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0e, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x12, 0x2c,
+	0x0a, 0x11, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x65, 0x63, 0x69,
+	0x6d, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x71, 0x75, 0x6f, 0x74, 0x65,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x12, 0x32, 0x0a, 0x14,
+	0x6d, 0x69, 0x6e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x65, 0x63,
+	0x69, 0x6d, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14, 0x6d, 0x69, 0x6e, 0x51,
+	0x75, 0x6f, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c,
+	0x12, 0x32, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x14,
+	0x6d, 0x61, 0x78, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x44, 0x65, 0x63,
+	0x69, 0x6d, 0x61, 0x6c, 0x22, 0x6d, 0x0a, 0x0e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x14,
+	0x0a, 0x05, 0x70, 0x61, 0x70, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x70,
+	0x61, 0x70, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x42,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x73, 0x22, 0xa0, 0x01, 0x0a, 0x16, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x48,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31,
+	0x0a, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x73, 0x12, 0x2d, 0x0a, 0x06, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x42, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x73,
+	0x12, 0x24, 0x0a, 0x0d, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x43, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0x4a, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x53, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x73, 0x22, 0x5e, 0x0a, 0x16, 0x53, 0x65, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x73, 0x22, 0x44, 0x0a, 0x14, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x61, 0x6e,
+	0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x22, 0xa7, 0x03, 0x0a, 0x0c, 0x44, 0x65, 0x61,
+	0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03,
+	0x61, 0x6c, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x12, 0x36, 0x0a,
+	0x08, 0x64, 0x61, 0x74, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x64, 0x61, 0x74,
+	0x65, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x32, 0x0a, 0x06, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x06, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x61,
+	0x6c, 0x49, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x61, 0x6c,
+	0x49, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x37, 0x0a,
+	0x06, 0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e,
+	0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x06,
+	0x73, 0x6f, 0x72, 0x74, 0x42, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65, 0x73, 0x63,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x3a, 0x0a, 0x09, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x41,
+	0x54, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x45, 0x4c, 0x54, 0x41, 0x5f, 0x50, 0x45, 0x52,
+	0x43, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x4d, 0x4f, 0x55, 0x4e, 0x54,
+	0x10, 0x02, 0x22, 0xd2, 0x06, 0x0a, 0x04, 0x44, 0x65, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x64,
+	0x65, 0x61, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x61,
+	0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x38, 0x0a, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x02, 0x42, 0x02, 0x18, 0x01, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x12, 0x2a, 0x0a, 0x0e, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x63, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x02, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0e, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x24, 0x0a,
+	0x0b, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x02, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0b, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0c, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x50, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x02, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0c, 0x64,
+	0x65, 0x6c, 0x74, 0x61, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x0a, 0x70,
+	0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x2e, 0x44,
+	0x65, 0x61, 0x6c, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70,
+	0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x70,
+	0x65, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x70, 0x61, 0x70, 0x65, 0x72, 0x12,
+	0x20, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x6e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x02, 0x42, 0x02, 0x18, 0x01, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x6e, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x65, 0x63, 0x69, 0x6d,
+	0x61, 0x6c, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x12, 0x34, 0x0a, 0x15, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c,
+	0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x43, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x12, 0x2e, 0x0a,
+	0x12, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x65, 0x63, 0x69,
+	0x6d, 0x61, 0x6c, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x64, 0x65, 0x6c, 0x74, 0x61,
+	0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x12, 0x30, 0x0a,
+	0x13, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x63,
+	0x69, 0x6d, 0x61, 0x6c, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x64, 0x65, 0x6c, 0x74,
+	0x61, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x12,
+	0x2a, 0x0a, 0x10, 0x6f, 0x70, 0x65, 0x6e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x44, 0x65, 0x63, 0x69,
+	0x6d, 0x61, 0x6c, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6f, 0x70, 0x65, 0x6e, 0x50,
+	0x72, 0x69, 0x63, 0x65, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x30, 0x0a, 0x13, 0x6d, 0x61, 0x78,
+	0x46, 0x61, 0x76, 0x6f, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x18, 0x21, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x46, 0x61, 0x76, 0x6f, 0x72,
+	0x61, 0x62, 0x6c, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x11, 0x6d,
+	0x61, 0x78, 0x41, 0x64, 0x76, 0x65, 0x72, 0x73, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x18, 0x23, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x41, 0x64, 0x76, 0x65, 0x72,
+	0x73, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x48, 0x69, 0x74, 0x18, 0x25, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x48, 0x69, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x27, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x1a,
+	0x36, 0x0a, 0x0e, 0x44, 0x65, 0x61, 0x6c, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52,
+	0x04, 0x73, 0x74, 0x6f, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x02, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0x5a, 0x0a, 0x0d, 0x44, 0x65, 0x61, 0x6c, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x64, 0x65, 0x61, 0x6c,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c,
+	0x66, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x52, 0x05, 0x64, 0x65, 0x61, 0x6c, 0x73, 0x12, 0x24, 0x0a,
+	0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x22, 0x31, 0x0a, 0x0c, 0x44, 0x65, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x04, 0x64, 0x65, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x44, 0x65, 0x61, 0x6c,
+	0x52, 0x04, 0x64, 0x65, 0x61, 0x6c, 0x22, 0xe6, 0x01, 0x0a, 0x14, 0x4f, 0x70, 0x65, 0x6e, 0x50,
+	0x61, 0x70, 0x65, 0x72, 0x44, 0x65, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12,
+	0x2a, 0x0a, 0x0e, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x42, 0x02, 0x18, 0x01, 0x52, 0x0e, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x3c, 0x0a, 0x0a, 0x70,
+	0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x2e, 0x44,
+	0x65, 0x61, 0x6c, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x70,
+	0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x34, 0x0a, 0x15, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x44, 0x65, 0x63, 0x69, 0x6d,
+	0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x22,
+	0xd7, 0x01, 0x0a, 0x0d, 0x50, 0x6f, 0x74, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x61,
+	0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x63, 0x74,
+	0x75, 0x61, 0x6c, 0x52, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0a, 0x61,
+	0x63, 0x74, 0x75, 0x61, 0x6c, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x6c, 0x61,
+	0x6e, 0x52, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x70, 0x6c, 0x61,
+	0x6e, 0x52, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x46, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x63, 0x74,
+	0x75, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b,
+	0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x1c, 0x0a, 0x09, 0x70,
+	0x6c, 0x61, 0x6e, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09,
+	0x70, 0x6c, 0x61, 0x6e, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x22, 0x44, 0x0a, 0x16, 0x50, 0x6f, 0x74,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x04, 0x64, 0x65, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x50, 0x6f, 0x74, 0x65,
+	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x61, 0x6c, 0x52, 0x04, 0x64, 0x65, 0x61, 0x6c, 0x22,
+	0x41, 0x0a, 0x13, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x6e, 0x6b, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x6e, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x22, 0x7a, 0x0a, 0x0c, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x73, 0x12, 0x38, 0x0a, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66,
+	0x2e, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61,
+	0x6e, 0x6e, 0x65, 0x6c, 0x52, 0x08, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x73, 0x22, 0x51,
+	0x0a, 0x14, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67,
+	0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x22, 0x6b, 0x0a, 0x16, 0x53, 0x65, 0x74, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x61, 0x6e, 0x64,
+	0x61, 0x6c, 0x66, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x66,
+	0x0a, 0x14, 0x45, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x48, 0x61, 0x6c, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0xc4, 0x02, 0x0a, 0x08, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x3c, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e, 0x67, 0x61,
+	0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x72, 0x6f,
+	0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x72, 0x6f, 0x6e, 0x12, 0x38, 0x0a,
+	0x09, 0x6e, 0x65, 0x78, 0x74, 0x52, 0x75, 0x6e, 0x41, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x6e, 0x65,
+	0x78, 0x74, 0x52, 0x75, 0x6e, 0x41, 0x74, 0x12, 0x38, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x52,
+	0x75, 0x6e, 0x41, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x75, 0x6e, 0x41,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12,
+	0x1c, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x42, 0x79, 0x22, 0xd1, 0x01,
+	0x0a, 0x1b, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x3c, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24, 0x2e,
+	0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x30, 0x0a, 0x05, 0x72,
+	0x75, 0x6e, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x72, 0x75, 0x6e, 0x41, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x63, 0x72, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x72, 0x6f,
+	0x6e, 0x22, 0x41, 0x0a, 0x10, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x08, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c,
+	0x66, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x08, 0x73, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x22, 0x44, 0x0a, 0x11, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x09, 0x73, 0x63, 0x68,
+	0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67,
+	0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52,
+	0x09, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x4f, 0x0a, 0x15, 0x43, 0x61,
+	0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x73,
+	0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x49, 0x64, 0x22, 0x62, 0x0a, 0x16, 0x47,
+	0x65, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x22,
+	0xa1, 0x01, 0x0a, 0x16, 0x53, 0x65, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x2d, 0x0a, 0x06, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x61, 0x6e,
+	0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x28, 0x0a, 0x0f, 0x65, 0x78, 0x70,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x8e, 0x01, 0x0a, 0x14, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x2d, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x06, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x2f, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x07, 0x68, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x22, 0xb6, 0x01, 0x0a, 0x16, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x73, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x08, 0x64, 0x61, 0x74, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x32, 0x0a, 0x06, 0x64, 0x61, 0x74,
+	0x65, 0x54, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x06, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x22, 0xf1, 0x03,
+	0x0a, 0x0f, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x66, 0x72, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x61, 0x6c, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x65, 0x61, 0x6c, 0x73, 0x12, 0x22, 0x0a,
+	0x0c, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x69, 0x74, 0x46, 0x69, 0x72, 0x73, 0x74, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x69, 0x74, 0x46, 0x69, 0x72, 0x73,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x48, 0x69, 0x74, 0x46, 0x69, 0x72, 0x73, 0x74,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x48, 0x69, 0x74, 0x46, 0x69,
+	0x72, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x6f, 0x6e, 0x65, 0x48, 0x69, 0x74, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x6e, 0x6f, 0x6e, 0x65, 0x48, 0x69, 0x74, 0x12, 0x22, 0x0a,
+	0x0c, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x69, 0x74, 0x52, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0c, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x69, 0x74, 0x52, 0x61, 0x74, 0x69,
+	0x6f, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x48, 0x69, 0x74, 0x52, 0x61, 0x74, 0x69, 0x6f,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x48, 0x69, 0x74, 0x52, 0x61,
+	0x74, 0x69, 0x6f, 0x12, 0x2a, 0x0a, 0x10, 0x61, 0x76, 0x67, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63,
+	0x74, 0x65, 0x64, 0x53, 0x74, 0x6f, 0x70, 0x18, 0x11, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x61,
+	0x76, 0x67, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x65, 0x64, 0x53, 0x74, 0x6f, 0x70, 0x12,
+	0x28, 0x0a, 0x0f, 0x61, 0x76, 0x67, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x65, 0x64, 0x4d,
+	0x61, 0x78, 0x18, 0x13, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x61, 0x76, 0x67, 0x50, 0x72, 0x65,
+	0x64, 0x69, 0x63, 0x74, 0x65, 0x64, 0x4d, 0x61, 0x78, 0x12, 0x32, 0x0a, 0x14, 0x61, 0x76, 0x67,
+	0x4d, 0x61, 0x78, 0x41, 0x64, 0x76, 0x65, 0x72, 0x73, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x01, 0x52, 0x14, 0x61, 0x76, 0x67, 0x4d, 0x61, 0x78, 0x41,
+	0x64, 0x76, 0x65, 0x72, 0x73, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x36, 0x0a,
+	0x16, 0x61, 0x76, 0x67, 0x4d, 0x61, 0x78, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x61, 0x62, 0x6c, 0x65,
+	0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x01, 0x52, 0x16, 0x61,
+	0x76, 0x67, 0x4d, 0x61, 0x78, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x61, 0x76, 0x67, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x19, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x10, 0x61, 0x76, 0x67, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x22, 0x49, 0x0a, 0x17, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x61,
+	0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0xc1, 0x02, 0x0a,
+	0x12, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x08, 0x64,
+	0x61, 0x74, 0x65, 0x46, 0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x64, 0x61, 0x74, 0x65, 0x46,
+	0x72, 0x6f, 0x6d, 0x12, 0x32, 0x0a, 0x06, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x06, 0x64, 0x61, 0x74, 0x65, 0x54, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x73, 0x12, 0x3a, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x22, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x46,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a,
+	0x6f, 0x6e, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a,
+	0x6f, 0x6e, 0x65, 0x22, 0x1b, 0x0a, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x07, 0x0a,
+	0x03, 0x43, 0x53, 0x56, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x58, 0x4c, 0x53, 0x58, 0x10, 0x01,
+	0x22, 0x5f, 0x0a, 0x0b, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d,
+	0x65, 0x22, 0x89, 0x01, 0x0a, 0x0d, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74,
+	0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xba, 0x02,
+	0x0a, 0x08, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70,
+	0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65,
+	0x74, 0x68, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x79, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x38, 0x0a, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x2e, 0x0a, 0x06, 0x65, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x61, 0x6e,
+	0x64, 0x61, 0x6c, 0x66, 0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x61, 0x0a, 0x0f, 0x41, 0x70,
+	0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75,
+	0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61,
+	0x6c, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x70, 0x72, 0x6f,
+	0x76, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x41, 0x0a,
+	0x10, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2d, 0x0a, 0x08, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x41, 0x70,
+	0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x52, 0x08, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c,
+	0x22, 0x44, 0x0a, 0x11, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x09, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61,
+	0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61,
+	0x6c, 0x66, 0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x52, 0x09, 0x61, 0x70, 0x70,
+	0x72, 0x6f, 0x76, 0x61, 0x6c, 0x73, 0x32, 0xb1, 0x10, 0x0a, 0x07, 0x47, 0x61, 0x6e, 0x64, 0x61,
+	0x6c, 0x66, 0x12, 0x54, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67,
+	0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x12, 0x1e, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c,
+	0x66, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c,
+	0x66, 0x2e, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x14, 0x53, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65,
+	0x12, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61,
+	0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x44, 0x0a, 0x12, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e,
+	0x67, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66,
+	0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x11, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x16, 0x2e, 0x67, 0x61,
+	0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x14, 0x53,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x54, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x53, 0x75, 0x73, 0x70,
+	0x65, 0x6e, 0x64, 0x12, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x61,
+	0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x13, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x54, 0x72, 0x61,
+	0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x16, 0x2e, 0x67, 0x61, 0x6e,
+	0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x54, 0x0a, 0x11, 0x47, 0x65,
+	0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12,
+	0x1e, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1f, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x54, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1e, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e,
+	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e,
+	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x1f, 0x2e, 0x67, 0x61, 0x6e, 0x64,
+	0x61, 0x6c, 0x66, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x61, 0x6e,
+	0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0f, 0x53, 0x65, 0x74,
+	0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x1f, 0x2e, 0x67,
+	0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x41, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x12, 0x15, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c,
+	0x66, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4b, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x6f, 0x74,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x12, 0x15, 0x2e, 0x67, 0x61,
+	0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x50, 0x6f, 0x74,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0a, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x44, 0x65, 0x61, 0x6c,
+	0x73, 0x12, 0x15, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x44, 0x65, 0x61, 0x6c,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61,
+	0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x45, 0x0a, 0x0d, 0x4f, 0x70, 0x65, 0x6e, 0x50, 0x61, 0x70, 0x65, 0x72, 0x44, 0x65, 0x61,
+	0x6c, 0x12, 0x1d, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x4f, 0x70, 0x65, 0x6e,
+	0x50, 0x61, 0x70, 0x65, 0x72, 0x44, 0x65, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x15, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x44, 0x65, 0x61, 0x6c, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x47, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x15, 0x2e, 0x67, 0x61, 0x6e,
+	0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x4a, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x65,
+	0x74, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46, 0x0a, 0x0d,
+	0x45, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63, 0x79, 0x48, 0x61, 0x6c, 0x74, 0x12, 0x1d, 0x2e,
+	0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63,
+	0x79, 0x48, 0x61, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
+	0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x0f, 0x45, 0x6d, 0x65, 0x72, 0x67, 0x65, 0x6e, 0x63,
+	0x79, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x15, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c,
+	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1f, 0x2e, 0x67, 0x61, 0x6e, 0x64,
+	0x61, 0x6c, 0x66, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x67, 0x61, 0x6e,
+	0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x51, 0x0a, 0x0f, 0x53, 0x65, 0x74,
+	0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1f, 0x2e, 0x67,
+	0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x65, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
+	0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x12,
+	0x47, 0x65, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x1f, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x50, 0x72, 0x65,
+	0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x50, 0x72,
+	0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x14, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x24, 0x2e,
+	0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x53, 0x63,
+	0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42,
+	0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12,
+	0x15, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66,
+	0x2e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x48, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65,
+	0x64, 0x75, 0x6c, 0x65, 0x12, 0x1e, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x42, 0x0a, 0x0b,
+	0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x61, 0x6c, 0x73, 0x12, 0x1b, 0x2e, 0x67, 0x61,
+	0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x44, 0x65, 0x61, 0x6c,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61,
+	0x6c, 0x66, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01,
+	0x12, 0x49, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x41,
+	0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x73, 0x12, 0x15, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61,
+	0x6c, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76,
+	0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x41,
+	0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x12, 0x18, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66,
+	0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f,
+	0x76, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x52,
+	0x65, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x18, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e,
+	0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x19, 0x2e, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x2e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76,
+	0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0e, 0x5a, 0x0c, 0x70, 0x62,
+	0x3b, 0x67, 0x61, 0x6e, 0x64, 0x61, 0x6c, 0x66, 0x50, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_pb_service_proto_rawDescOnce sync.Once
+	file_pb_service_proto_rawDescData = file_pb_service_proto_rawDesc
+)
+
+func file_pb_service_proto_rawDescGZIP() []byte {
+	file_pb_service_proto_rawDescOnce.Do(func() {
+		file_pb_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_pb_service_proto_rawDescData)
+	})
+	return file_pb_service_proto_rawDescData
+}
+
+var file_pb_service_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_pb_service_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
+var file_pb_service_proto_goTypes = []interface{}{
+	(TradingSymbol_TradingStatus)(0),    // 0: gandalf.TradingSymbol.TradingStatus
+	(BalanceHistoryRequest_Interval)(0), // 1: gandalf.BalanceHistoryRequest.Interval
+	(DealsRequest_SortField)(0),         // 2: gandalf.DealsRequest.SortField
+	(ExportDealsRequest_Format)(0),      // 3: gandalf.ExportDealsRequest.Format
+	(*EmptyRequest)(nil),                // 4: gandalf.EmptyRequest
+	(*EmptyResponse)(nil),               // 5: gandalf.EmptyResponse
+	(*TradingSymbol)(nil),               // 6: gandalf.TradingSymbol
+	(*SymbolConfig)(nil),                // 7: gandalf.SymbolConfig
+	(*TradingSymbolsRequest)(nil),       // 8: gandalf.TradingSymbolsRequest
+	(*TradingSymbolsResponse)(nil),      // 9: gandalf.TradingSymbolsResponse
+	(*SymbolRequest)(nil),               // 10: gandalf.SymbolRequest
+	(*SymbolBalancesRequest)(nil),       // 11: gandalf.SymbolBalancesRequest
+	(*SymbolBalance)(nil),               // 12: gandalf.SymbolBalance
+	(*SymbolBalancesResponse)(nil),      // 13: gandalf.SymbolBalancesResponse
+	(*SymbolLimit)(nil),                 // 14: gandalf.SymbolLimit
+	(*BalanceHistoryRequest)(nil),       // 15: gandalf.BalanceHistoryRequest
+	(*BalancePoint)(nil),                // 16: gandalf.BalancePoint
+	(*BalanceHistory)(nil),              // 17: gandalf.BalanceHistory
+	(*BalanceHistoryResponse)(nil),      // 18: gandalf.BalanceHistoryResponse
+	(*GetSymbolLimitsRequest)(nil),      // 19: gandalf.GetSymbolLimitsRequest
+	(*SetSymbolLimitsRequest)(nil),      // 20: gandalf.SetSymbolLimitsRequest
+	(*SymbolLimitsResponse)(nil),        // 21: gandalf.SymbolLimitsResponse
+	(*DealsRequest)(nil),                // 22: gandalf.DealsRequest
+	(*Deal)(nil),                        // 23: gandalf.Deal
+	(*DealsResponse)(nil),               // 24: gandalf.DealsResponse
+	(*DealResponse)(nil),                // 25: gandalf.DealResponse
+	(*OpenPaperDealRequest)(nil),        // 26: gandalf.OpenPaperDealRequest
+	(*PotentialDeal)(nil),               // 27: gandalf.PotentialDeal
+	(*PotentialDealsResponse)(nil),      // 28: gandalf.PotentialDealsResponse
+	(*NotificationChannel)(nil),         // 29: gandalf.NotificationChannel
+	(*Subscription)(nil),                // 30: gandalf.Subscription
+	(*SubscriptionResponse)(nil),        // 31: gandalf.SubscriptionResponse
+	(*SetSubscriptionRequest)(nil),      // 32: gandalf.SetSubscriptionRequest
+	(*EmergencyHaltRequest)(nil),        // 33: gandalf.EmergencyHaltRequest
+	(*Schedule)(nil),                    // 34: gandalf.Schedule
+	(*ScheduleStatusChangeRequest)(nil), // 35: gandalf.ScheduleStatusChangeRequest
+	(*ScheduleResponse)(nil),            // 36: gandalf.ScheduleResponse
+	(*SchedulesResponse)(nil),           // 37: gandalf.SchedulesResponse
+	(*CancelScheduleRequest)(nil),       // 38: gandalf.CancelScheduleRequest
+	(*GetSymbolConfigRequest)(nil),      // 39: gandalf.GetSymbolConfigRequest
+	(*SetSymbolConfigRequest)(nil),      // 40: gandalf.SetSymbolConfigRequest
+	(*SymbolConfigResponse)(nil),        // 41: gandalf.SymbolConfigResponse
+	(*PredictionStatsRequest)(nil),      // 42: gandalf.PredictionStatsRequest
+	(*PredictionStats)(nil),             // 43: gandalf.PredictionStats
+	(*PredictionStatsResponse)(nil),     // 44: gandalf.PredictionStatsResponse
+	(*ExportDealsRequest)(nil),          // 45: gandalf.ExportDealsRequest
+	(*ExportChunk)(nil),                 // 46: gandalf.ExportChunk
+	(*ApprovalEvent)(nil),               // 47: gandalf.ApprovalEvent
+	(*Approval)(nil),                    // 48: gandalf.Approval
+	(*ApprovalRequest)(nil),             // 49: gandalf.ApprovalRequest
+	(*ApprovalResponse)(nil),            // 50: gandalf.ApprovalResponse
+	(*ApprovalsResponse)(nil),           // 51: gandalf.ApprovalsResponse
+	(*Deal_DealPrediction)(nil),         // 52: gandalf.Deal.DealPrediction
+	(*timestamp.Timestamp)(nil),         // 53: google.protobuf.Timestamp
+}
+var file_pb_service_proto_depIdxs = []int32{
+	0,  // 0: gandalf.TradingSymbol.status:type_name -> gandalf.TradingSymbol.TradingStatus
+	7,  // 1: gandalf.TradingSymbol.config:type_name -> gandalf.SymbolConfig
+	53, // 2: gandalf.SymbolConfig.updatedAt:type_name -> google.protobuf.Timestamp
+	6,  // 3: gandalf.TradingSymbolsResponse.symbols:type_name -> gandalf.TradingSymbol
+	7,  // 4: gandalf.SymbolRequest.config:type_name -> gandalf.SymbolConfig
+	12, // 5: gandalf.SymbolBalancesResponse.balances:type_name -> gandalf.SymbolBalance
+	12, // 6: gandalf.SymbolBalancesResponse.paperBalances:type_name -> gandalf.SymbolBalance
+	53, // 7: gandalf.BalanceHistoryRequest.dateFrom:type_name -> google.protobuf.Timestamp
+	53, // 8: gandalf.BalanceHistoryRequest.dateTo:type_name -> google.protobuf.Timestamp
+	1,  // 9: gandalf.BalanceHistoryRequest.interval:type_name -> gandalf.BalanceHistoryRequest.Interval
+	53, // 10: gandalf.BalancePoint.time:type_name -> google.protobuf.Timestamp
+	16, // 11: gandalf.BalanceHistory.points:type_name -> gandalf.BalancePoint
+	17, // 12: gandalf.BalanceHistoryResponse.symbols:type_name -> gandalf.BalanceHistory
+	16, // 13: gandalf.BalanceHistoryResponse.totals:type_name -> gandalf.BalancePoint
+	14, // 14: gandalf.SetSymbolLimitsRequest.limits:type_name -> gandalf.SymbolLimit
+	14, // 15: gandalf.SymbolLimitsResponse.limits:type_name -> gandalf.SymbolLimit
+	53, // 16: gandalf.DealsRequest.dateFrom:type_name -> google.protobuf.Timestamp
+	53, // 17: gandalf.DealsRequest.dateTo:type_name -> google.protobuf.Timestamp
+	2,  // 18: gandalf.DealsRequest.sortBy:type_name -> gandalf.DealsRequest.SortField
+	53, // 19: gandalf.Deal.createdAt:type_name -> google.protobuf.Timestamp
+	52, // 20: gandalf.Deal.prediction:type_name -> gandalf.Deal.DealPrediction
+	23, // 21: gandalf.DealsResponse.deals:type_name -> gandalf.Deal
+	23, // 22: gandalf.DealResponse.deal:type_name -> gandalf.Deal
+	52, // 23: gandalf.OpenPaperDealRequest.prediction:type_name -> gandalf.Deal.DealPrediction
+	27, // 24: gandalf.PotentialDealsResponse.deal:type_name -> gandalf.PotentialDeal
+	29, // 25: gandalf.Subscription.channels:type_name -> gandalf.NotificationChannel
+	30, // 26: gandalf.SubscriptionResponse.subscription:type_name -> gandalf.Subscription
+	30, // 27: gandalf.SetSubscriptionRequest.subscription:type_name -> gandalf.Subscription
+	0,  // 28: gandalf.Schedule.status:type_name -> gandalf.TradingSymbol.TradingStatus
+	53, // 29: gandalf.Schedule.nextRunAt:type_name -> google.protobuf.Timestamp
+	53, // 30: gandalf.Schedule.lastRunAt:type_name -> google.protobuf.Timestamp
+	0,  // 31: gandalf.ScheduleStatusChangeRequest.status:type_name -> gandalf.TradingSymbol.TradingStatus
+	53, // 32: gandalf.ScheduleStatusChangeRequest.runAt:type_name -> google.protobuf.Timestamp
+	34, // 33: gandalf.ScheduleResponse.schedule:type_name -> gandalf.Schedule
+	34, // 34: gandalf.SchedulesResponse.schedules:type_name -> gandalf.Schedule
+	7,  // 35: gandalf.SetSymbolConfigRequest.config:type_name -> gandalf.SymbolConfig
+	7,  // 36: gandalf.SymbolConfigResponse.config:type_name -> gandalf.SymbolConfig
+	7,  // 37: gandalf.SymbolConfigResponse.history:type_name -> gandalf.SymbolConfig
+	53, // 38: gandalf.PredictionStatsRequest.dateFrom:type_name -> google.protobuf.Timestamp
+	53, // 39: gandalf.PredictionStatsRequest.dateTo:type_name -> google.protobuf.Timestamp
+	43, // 40: gandalf.PredictionStatsResponse.stats:type_name -> gandalf.PredictionStats
+	53, // 41: gandalf.ExportDealsRequest.dateFrom:type_name -> google.protobuf.Timestamp
+	53, // 42: gandalf.ExportDealsRequest.dateTo:type_name -> google.protobuf.Timestamp
+	3,  // 43: gandalf.ExportDealsRequest.format:type_name -> gandalf.ExportDealsRequest.Format
+	53, // 44: gandalf.ApprovalEvent.time:type_name -> google.protobuf.Timestamp
+	53, // 45: gandalf.Approval.createdAt:type_name -> google.protobuf.Timestamp
+	53, // 46: gandalf.Approval.expiresAt:type_name -> google.protobuf.Timestamp
+	47, // 47: gandalf.Approval.events:type_name -> gandalf.ApprovalEvent
+	48, // 48: gandalf.ApprovalResponse.approval:type_name -> gandalf.Approval
+	48, // 49: gandalf.ApprovalsResponse.approvals:type_name -> gandalf.Approval
+	8,  // 50: gandalf.Gandalf.GetTradingSymbols:input_type -> gandalf.TradingSymbolsRequest
+	10, // 51: gandalf.Gandalf.SymbolTradingPrepare:input_type -> gandalf.SymbolRequest
+	10, // 52: gandalf.Gandalf.SymbolTradingStart:input_type -> gandalf.SymbolRequest
+	10, // 53: gandalf.Gandalf.SymbolTradingStop:input_type -> gandalf.SymbolRequest
+	10, // 54: gandalf.Gandalf.SymbolTradingSuspend:input_type -> gandalf.SymbolRequest
+	10, // 55: gandalf.Gandalf.SymbolTradingResume:input_type -> gandalf.SymbolRequest
+	11, // 56: gandalf.Gandalf.GetSymbolBalances:input_type -> gandalf.SymbolBalancesRequest
+	15, // 57: gandalf.Gandalf.GetBalanceHistory:input_type -> gandalf.BalanceHistoryRequest
+	19, // 58: gandalf.Gandalf.GetSymbolLimits:input_type -> gandalf.GetSymbolLimitsRequest
+	20, // 59: gandalf.Gandalf.SetSymbolLimits:input_type -> gandalf.SetSymbolLimitsRequest
+	22, // 60: gandalf.Gandalf.GetActiveDeals:input_type -> gandalf.DealsRequest
+	22, // 61: gandalf.Gandalf.GetPotentialDeals:input_type -> gandalf.DealsRequest
+	22, // 62: gandalf.Gandalf.CloseDeals:input_type -> gandalf.DealsRequest
+	26, // 63: gandalf.Gandalf.OpenPaperDeal:input_type -> gandalf.OpenPaperDealRequest
+	4,  // 64: gandalf.Gandalf.GetSubscription:input_type -> gandalf.EmptyRequest
+	32, // 65: gandalf.Gandalf.SetSubscription:input_type -> gandalf.SetSubscriptionRequest
+	33, // 66: gandalf.Gandalf.EmergencyHalt:input_type -> gandalf.EmergencyHaltRequest
+	4,  // 67: gandalf.Gandalf.EmergencyResume:input_type -> gandalf.EmptyRequest
+	39, // 68: gandalf.Gandalf.GetSymbolConfig:input_type -> gandalf.GetSymbolConfigRequest
+	40, // 69: gandalf.Gandalf.SetSymbolConfig:input_type -> gandalf.SetSymbolConfigRequest
+	42, // 70: gandalf.Gandalf.GetPredictionStats:input_type -> gandalf.PredictionStatsRequest
+	35, // 71: gandalf.Gandalf.ScheduleStatusChange:input_type -> gandalf.ScheduleStatusChangeRequest
+	4,  // 72: gandalf.Gandalf.ListSchedules:input_type -> gandalf.EmptyRequest
+	38, // 73: gandalf.Gandalf.CancelSchedule:input_type -> gandalf.CancelScheduleRequest
+	45, // 74: gandalf.Gandalf.ExportDeals:input_type -> gandalf.ExportDealsRequest
+	4,  // 75: gandalf.Gandalf.ListPendingApprovals:input_type -> gandalf.EmptyRequest
+	49, // 76: gandalf.Gandalf.Approve:input_type -> gandalf.ApprovalRequest
+	49, // 77: gandalf.Gandalf.Reject:input_type -> gandalf.ApprovalRequest
+	9,  // 78: gandalf.Gandalf.GetTradingSymbols:output_type -> gandalf.TradingSymbolsResponse
+	5,  // 79: gandalf.Gandalf.SymbolTradingPrepare:output_type -> gandalf.EmptyResponse
+	5,  // 80: gandalf.Gandalf.SymbolTradingStart:output_type -> gandalf.EmptyResponse
+	5,  // 81: gandalf.Gandalf.SymbolTradingStop:output_type -> gandalf.EmptyResponse
+	5,  // 82: gandalf.Gandalf.SymbolTradingSuspend:output_type -> gandalf.EmptyResponse
+	5,  // 83: gandalf.Gandalf.SymbolTradingResume:output_type -> gandalf.EmptyResponse
+	13, // 84: gandalf.Gandalf.GetSymbolBalances:output_type -> gandalf.SymbolBalancesResponse
+	18, // 85: gandalf.Gandalf.GetBalanceHistory:output_type -> gandalf.BalanceHistoryResponse
+	21, // 86: gandalf.Gandalf.GetSymbolLimits:output_type -> gandalf.SymbolLimitsResponse
+	5,  // 87: gandalf.Gandalf.SetSymbolLimits:output_type -> gandalf.EmptyResponse
+	24, // 88: gandalf.Gandalf.GetActiveDeals:output_type -> gandalf.DealsResponse
+	28, // 89: gandalf.Gandalf.GetPotentialDeals:output_type -> gandalf.PotentialDealsResponse
+	5,  // 90: gandalf.Gandalf.CloseDeals:output_type -> gandalf.EmptyResponse
+	25, // 91: gandalf.Gandalf.OpenPaperDeal:output_type -> gandalf.DealResponse
+	31, // 92: gandalf.Gandalf.GetSubscription:output_type -> gandalf.SubscriptionResponse
+	5,  // 93: gandalf.Gandalf.SetSubscription:output_type -> gandalf.EmptyResponse
+	5,  // 94: gandalf.Gandalf.EmergencyHalt:output_type -> gandalf.EmptyResponse
+	5,  // 95: gandalf.Gandalf.EmergencyResume:output_type -> gandalf.EmptyResponse
+	41, // 96: gandalf.Gandalf.GetSymbolConfig:output_type -> gandalf.SymbolConfigResponse
+	41, // 97: gandalf.Gandalf.SetSymbolConfig:output_type -> gandalf.SymbolConfigResponse
+	44, // 98: gandalf.Gandalf.GetPredictionStats:output_type -> gandalf.PredictionStatsResponse
+	36, // 99: gandalf.Gandalf.ScheduleStatusChange:output_type -> gandalf.ScheduleResponse
+	37, // 100: gandalf.Gandalf.ListSchedules:output_type -> gandalf.SchedulesResponse
+	5,  // 101: gandalf.Gandalf.CancelSchedule:output_type -> gandalf.EmptyResponse
+	46, // 102: gandalf.Gandalf.ExportDeals:output_type -> gandalf.ExportChunk
+	51, // 103: gandalf.Gandalf.ListPendingApprovals:output_type -> gandalf.ApprovalsResponse
+	50, // 104: gandalf.Gandalf.Approve:output_type -> gandalf.ApprovalResponse
+	50, // 105: gandalf.Gandalf.Reject:output_type -> gandalf.ApprovalResponse
+	78, // [78:106] is the sub-list for method output_type
+	50, // [50:78] is the sub-list for method input_type
+	50, // [50:50] is the sub-list for extension type_name
+	50, // [50:50] is the sub-list for extension extendee
+	0,  // [0:50] is the sub-list for field type_name
+}
+
+func init() { file_pb_service_proto_init() }
+func file_pb_service_proto_init() {
+	if File_pb_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_pb_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmptyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmptyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TradingSymbol); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SymbolConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TradingSymbolsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TradingSymbolsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SymbolRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SymbolBalancesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SymbolBalance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SymbolBalancesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SymbolLimit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BalanceHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BalancePoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BalanceHistory); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BalanceHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSymbolLimitsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetSymbolLimitsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SymbolLimitsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DealsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Deal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DealsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DealResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OpenPaperDealRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PotentialDeal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PotentialDealsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1339,8 +4901,8 @@ func file_pb_service_proto_init() {
 				return nil
 			}
 		}
-		file_pb_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EmptyResponse); i {
+		file_pb_service_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NotificationChannel); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1351,8 +4913,8 @@ func file_pb_service_proto_init() {
 				return nil
 			}
 		}
-		file_pb_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TradingSymbol); i {
+		file_pb_service_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Subscription); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1363,8 +4925,8 @@ func file_pb_service_proto_init() {
 				return nil
 			}
 		}
-		file_pb_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TradingSymbolsResponse); i {
+		file_pb_service_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1375,8 +4937,8 @@ func file_pb_service_proto_init() {
 				return nil
 			}
 		}
-		file_pb_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SymbolRequest); i {
+		file_pb_service_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetSubscriptionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1387,8 +4949,8 @@ func file_pb_service_proto_init() {
 				return nil
 			}
 		}
-		file_pb_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SymbolBalance); i {
+		file_pb_service_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EmergencyHaltRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1399,8 +4961,8 @@ func file_pb_service_proto_init() {
 				return nil
 			}
 		}
-		file_pb_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SymbolBalancesResponse); i {
+		file_pb_service_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Schedule); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1411,8 +4973,8 @@ func file_pb_service_proto_init() {
 				return nil
 			}
 		}
-		file_pb_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SymbolLimit); i {
+		file_pb_service_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleStatusChangeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1423,8 +4985,8 @@ func file_pb_service_proto_init() {
 				return nil
 			}
 		}
-		file_pb_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetSymbolLimitsRequest); i {
+		file_pb_service_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScheduleResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1435,8 +4997,8 @@ func file_pb_service_proto_init() {
 				return nil
 			}
 		}
-		file_pb_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SetSymbolLimitsRequest); i {
+		file_pb_service_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SchedulesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1447,8 +5009,8 @@ func file_pb_service_proto_init() {
 				return nil
 			}
 		}
-		file_pb_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SymbolLimitsResponse); i {
+		file_pb_service_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CancelScheduleRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1459,8 +5021,8 @@ func file_pb_service_proto_init() {
 				return nil
 			}
 		}
-		file_pb_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DealsRequest); i {
+		file_pb_service_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSymbolConfigRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1471,8 +5033,8 @@ func file_pb_service_proto_init() {
 				return nil
 			}
 		}
-		file_pb_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Deal); i {
+		file_pb_service_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SetSymbolConfigRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1483,8 +5045,8 @@ func file_pb_service_proto_init() {
 				return nil
 			}
 		}
-		file_pb_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DealsResponse); i {
+		file_pb_service_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SymbolConfigResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1495,8 +5057,8 @@ func file_pb_service_proto_init() {
 				return nil
 			}
 		}
-		file_pb_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PotentialDeal); i {
+		file_pb_service_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PredictionStatsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1507,8 +5069,8 @@ func file_pb_service_proto_init() {
 				return nil
 			}
 		}
-		file_pb_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PotentialDealsResponse); i {
+		file_pb_service_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PredictionStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1519,7 +5081,103 @@ func file_pb_service_proto_init() {
 				return nil
 			}
 		}
-		file_pb_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_pb_service_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PredictionStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportDealsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApprovalEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Approval); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApprovalRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApprovalResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ApprovalsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pb_service_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Deal_DealPrediction); i {
 			case 0:
 				return &v.state
@@ -1537,8 +5195,8 @@ func file_pb_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pb_service_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   17,
+			NumEnums:      4,
+			NumMessages:   49,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1565,18 +5223,34 @@ const _ = grpc.SupportPackageIsVersion6
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type GandalfClient interface {
-	GetTradingSymbols(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*TradingSymbolsResponse, error)
+	GetTradingSymbols(ctx context.Context, in *TradingSymbolsRequest, opts ...grpc.CallOption) (*TradingSymbolsResponse, error)
 	SymbolTradingPrepare(ctx context.Context, in *SymbolRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	SymbolTradingStart(ctx context.Context, in *SymbolRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	SymbolTradingStop(ctx context.Context, in *SymbolRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	SymbolTradingSuspend(ctx context.Context, in *SymbolRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	SymbolTradingResume(ctx context.Context, in *SymbolRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
-	GetSymbolBalances(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*SymbolBalancesResponse, error)
+	GetSymbolBalances(ctx context.Context, in *SymbolBalancesRequest, opts ...grpc.CallOption) (*SymbolBalancesResponse, error)
+	GetBalanceHistory(ctx context.Context, in *BalanceHistoryRequest, opts ...grpc.CallOption) (*BalanceHistoryResponse, error)
 	GetSymbolLimits(ctx context.Context, in *GetSymbolLimitsRequest, opts ...grpc.CallOption) (*SymbolLimitsResponse, error)
 	SetSymbolLimits(ctx context.Context, in *SetSymbolLimitsRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	GetActiveDeals(ctx context.Context, in *DealsRequest, opts ...grpc.CallOption) (*DealsResponse, error)
 	GetPotentialDeals(ctx context.Context, in *DealsRequest, opts ...grpc.CallOption) (*PotentialDealsResponse, error)
 	CloseDeals(ctx context.Context, in *DealsRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	OpenPaperDeal(ctx context.Context, in *OpenPaperDealRequest, opts ...grpc.CallOption) (*DealResponse, error)
+	GetSubscription(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*SubscriptionResponse, error)
+	SetSubscription(ctx context.Context, in *SetSubscriptionRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	EmergencyHalt(ctx context.Context, in *EmergencyHaltRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	EmergencyResume(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	GetSymbolConfig(ctx context.Context, in *GetSymbolConfigRequest, opts ...grpc.CallOption) (*SymbolConfigResponse, error)
+	SetSymbolConfig(ctx context.Context, in *SetSymbolConfigRequest, opts ...grpc.CallOption) (*SymbolConfigResponse, error)
+	GetPredictionStats(ctx context.Context, in *PredictionStatsRequest, opts ...grpc.CallOption) (*PredictionStatsResponse, error)
+	ScheduleStatusChange(ctx context.Context, in *ScheduleStatusChangeRequest, opts ...grpc.CallOption) (*ScheduleResponse, error)
+	ListSchedules(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*SchedulesResponse, error)
+	CancelSchedule(ctx context.Context, in *CancelScheduleRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	ExportDeals(ctx context.Context, in *ExportDealsRequest, opts ...grpc.CallOption) (Gandalf_ExportDealsClient, error)
+	ListPendingApprovals(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ApprovalsResponse, error)
+	Approve(ctx context.Context, in *ApprovalRequest, opts ...grpc.CallOption) (*ApprovalResponse, error)
+	Reject(ctx context.Context, in *ApprovalRequest, opts ...grpc.CallOption) (*ApprovalResponse, error)
 }
 
 type gandalfClient struct {
@@ -1587,7 +5261,7 @@ func NewGandalfClient(cc grpc.ClientConnInterface) GandalfClient {
 	return &gandalfClient{cc}
 }
 
-func (c *gandalfClient) GetTradingSymbols(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*TradingSymbolsResponse, error) {
+func (c *gandalfClient) GetTradingSymbols(ctx context.Context, in *TradingSymbolsRequest, opts ...grpc.CallOption) (*TradingSymbolsResponse, error) {
 	out := new(TradingSymbolsResponse)
 	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/GetTradingSymbols", in, out, opts...)
 	if err != nil {
@@ -1641,7 +5315,7 @@ func (c *gandalfClient) SymbolTradingResume(ctx context.Context, in *SymbolReque
 	return out, nil
 }
 
-func (c *gandalfClient) GetSymbolBalances(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*SymbolBalancesResponse, error) {
+func (c *gandalfClient) GetSymbolBalances(ctx context.Context, in *SymbolBalancesRequest, opts ...grpc.CallOption) (*SymbolBalancesResponse, error) {
 	out := new(SymbolBalancesResponse)
 	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/GetSymbolBalances", in, out, opts...)
 	if err != nil {
@@ -1650,6 +5324,15 @@ func (c *gandalfClient) GetSymbolBalances(ctx context.Context, in *EmptyRequest,
 	return out, nil
 }
 
+func (c *gandalfClient) GetBalanceHistory(ctx context.Context, in *BalanceHistoryRequest, opts ...grpc.CallOption) (*BalanceHistoryResponse, error) {
+	out := new(BalanceHistoryResponse)
+	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/GetBalanceHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gandalfClient) GetSymbolLimits(ctx context.Context, in *GetSymbolLimitsRequest, opts ...grpc.CallOption) (*SymbolLimitsResponse, error) {
 	out := new(SymbolLimitsResponse)
 	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/GetSymbolLimits", in, out, opts...)
@@ -1695,27 +5378,201 @@ func (c *gandalfClient) CloseDeals(ctx context.Context, in *DealsRequest, opts .
 	return out, nil
 }
 
+func (c *gandalfClient) OpenPaperDeal(ctx context.Context, in *OpenPaperDealRequest, opts ...grpc.CallOption) (*DealResponse, error) {
+	out := new(DealResponse)
+	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/OpenPaperDeal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gandalfClient) GetSubscription(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*SubscriptionResponse, error) {
+	out := new(SubscriptionResponse)
+	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/GetSubscription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gandalfClient) SetSubscription(ctx context.Context, in *SetSubscriptionRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/SetSubscription", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gandalfClient) EmergencyHalt(ctx context.Context, in *EmergencyHaltRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/EmergencyHalt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gandalfClient) EmergencyResume(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/EmergencyResume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gandalfClient) GetSymbolConfig(ctx context.Context, in *GetSymbolConfigRequest, opts ...grpc.CallOption) (*SymbolConfigResponse, error) {
+	out := new(SymbolConfigResponse)
+	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/GetSymbolConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gandalfClient) SetSymbolConfig(ctx context.Context, in *SetSymbolConfigRequest, opts ...grpc.CallOption) (*SymbolConfigResponse, error) {
+	out := new(SymbolConfigResponse)
+	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/SetSymbolConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gandalfClient) GetPredictionStats(ctx context.Context, in *PredictionStatsRequest, opts ...grpc.CallOption) (*PredictionStatsResponse, error) {
+	out := new(PredictionStatsResponse)
+	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/GetPredictionStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gandalfClient) ScheduleStatusChange(ctx context.Context, in *ScheduleStatusChangeRequest, opts ...grpc.CallOption) (*ScheduleResponse, error) {
+	out := new(ScheduleResponse)
+	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/ScheduleStatusChange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gandalfClient) ListSchedules(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*SchedulesResponse, error) {
+	out := new(SchedulesResponse)
+	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/ListSchedules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gandalfClient) CancelSchedule(ctx context.Context, in *CancelScheduleRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/CancelSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gandalfClient) ExportDeals(ctx context.Context, in *ExportDealsRequest, opts ...grpc.CallOption) (Gandalf_ExportDealsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Gandalf_serviceDesc.Streams[0], "/gandalf.Gandalf/ExportDeals", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gandalfExportDealsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Gandalf_ExportDealsClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type gandalfExportDealsClient struct {
+	grpc.ClientStream
+}
+
+func (x *gandalfExportDealsClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gandalfClient) ListPendingApprovals(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ApprovalsResponse, error) {
+	out := new(ApprovalsResponse)
+	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/ListPendingApprovals", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gandalfClient) Approve(ctx context.Context, in *ApprovalRequest, opts ...grpc.CallOption) (*ApprovalResponse, error) {
+	out := new(ApprovalResponse)
+	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/Approve", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gandalfClient) Reject(ctx context.Context, in *ApprovalRequest, opts ...grpc.CallOption) (*ApprovalResponse, error) {
+	out := new(ApprovalResponse)
+	err := c.cc.Invoke(ctx, "/gandalf.Gandalf/Reject", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GandalfServer is the server API for Gandalf service.
 type GandalfServer interface {
-	GetTradingSymbols(context.Context, *EmptyRequest) (*TradingSymbolsResponse, error)
+	GetTradingSymbols(context.Context, *TradingSymbolsRequest) (*TradingSymbolsResponse, error)
 	SymbolTradingPrepare(context.Context, *SymbolRequest) (*EmptyResponse, error)
 	SymbolTradingStart(context.Context, *SymbolRequest) (*EmptyResponse, error)
 	SymbolTradingStop(context.Context, *SymbolRequest) (*EmptyResponse, error)
 	SymbolTradingSuspend(context.Context, *SymbolRequest) (*EmptyResponse, error)
 	SymbolTradingResume(context.Context, *SymbolRequest) (*EmptyResponse, error)
-	GetSymbolBalances(context.Context, *EmptyRequest) (*SymbolBalancesResponse, error)
+	GetSymbolBalances(context.Context, *SymbolBalancesRequest) (*SymbolBalancesResponse, error)
+	GetBalanceHistory(context.Context, *BalanceHistoryRequest) (*BalanceHistoryResponse, error)
 	GetSymbolLimits(context.Context, *GetSymbolLimitsRequest) (*SymbolLimitsResponse, error)
 	SetSymbolLimits(context.Context, *SetSymbolLimitsRequest) (*EmptyResponse, error)
 	GetActiveDeals(context.Context, *DealsRequest) (*DealsResponse, error)
 	GetPotentialDeals(context.Context, *DealsRequest) (*PotentialDealsResponse, error)
 	CloseDeals(context.Context, *DealsRequest) (*EmptyResponse, error)
+	OpenPaperDeal(context.Context, *OpenPaperDealRequest) (*DealResponse, error)
+	GetSubscription(context.Context, *EmptyRequest) (*SubscriptionResponse, error)
+	SetSubscription(context.Context, *SetSubscriptionRequest) (*EmptyResponse, error)
+	EmergencyHalt(context.Context, *EmergencyHaltRequest) (*EmptyResponse, error)
+	EmergencyResume(context.Context, *EmptyRequest) (*EmptyResponse, error)
+	GetSymbolConfig(context.Context, *GetSymbolConfigRequest) (*SymbolConfigResponse, error)
+	SetSymbolConfig(context.Context, *SetSymbolConfigRequest) (*SymbolConfigResponse, error)
+	GetPredictionStats(context.Context, *PredictionStatsRequest) (*PredictionStatsResponse, error)
+	ScheduleStatusChange(context.Context, *ScheduleStatusChangeRequest) (*ScheduleResponse, error)
+	ListSchedules(context.Context, *EmptyRequest) (*SchedulesResponse, error)
+	CancelSchedule(context.Context, *CancelScheduleRequest) (*EmptyResponse, error)
+	ExportDeals(*ExportDealsRequest, Gandalf_ExportDealsServer) error
+	ListPendingApprovals(context.Context, *EmptyRequest) (*ApprovalsResponse, error)
+	Approve(context.Context, *ApprovalRequest) (*ApprovalResponse, error)
+	Reject(context.Context, *ApprovalRequest) (*ApprovalResponse, error)
 }
 
 // UnimplementedGandalfServer can be embedded to have forward compatible implementations.
 type UnimplementedGandalfServer struct {
 }
 
-func (*UnimplementedGandalfServer) GetTradingSymbols(context.Context, *EmptyRequest) (*TradingSymbolsResponse, error) {
+func (*UnimplementedGandalfServer) GetTradingSymbols(context.Context, *TradingSymbolsRequest) (*TradingSymbolsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTradingSymbols not implemented")
 }
 func (*UnimplementedGandalfServer) SymbolTradingPrepare(context.Context, *SymbolRequest) (*EmptyResponse, error) {
@@ -1730,26 +5587,74 @@ func (*UnimplementedGandalfServer) SymbolTradingStop(context.Context, *SymbolReq
 func (*UnimplementedGandalfServer) SymbolTradingSuspend(context.Context, *SymbolRequest) (*EmptyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SymbolTradingSuspend not implemented")
 }
-func (*UnimplementedGandalfServer) SymbolTradingResume(context.Context, *SymbolRequest) (*EmptyResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SymbolTradingResume not implemented")
+func (*UnimplementedGandalfServer) SymbolTradingResume(context.Context, *SymbolRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SymbolTradingResume not implemented")
+}
+func (*UnimplementedGandalfServer) GetSymbolBalances(context.Context, *SymbolBalancesRequest) (*SymbolBalancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSymbolBalances not implemented")
+}
+func (*UnimplementedGandalfServer) GetBalanceHistory(context.Context, *BalanceHistoryRequest) (*BalanceHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceHistory not implemented")
+}
+func (*UnimplementedGandalfServer) GetSymbolLimits(context.Context, *GetSymbolLimitsRequest) (*SymbolLimitsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSymbolLimits not implemented")
+}
+func (*UnimplementedGandalfServer) SetSymbolLimits(context.Context, *SetSymbolLimitsRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSymbolLimits not implemented")
+}
+func (*UnimplementedGandalfServer) GetActiveDeals(context.Context, *DealsRequest) (*DealsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActiveDeals not implemented")
+}
+func (*UnimplementedGandalfServer) GetPotentialDeals(context.Context, *DealsRequest) (*PotentialDealsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPotentialDeals not implemented")
+}
+func (*UnimplementedGandalfServer) CloseDeals(context.Context, *DealsRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloseDeals not implemented")
+}
+func (*UnimplementedGandalfServer) OpenPaperDeal(context.Context, *OpenPaperDealRequest) (*DealResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenPaperDeal not implemented")
+}
+func (*UnimplementedGandalfServer) GetSubscription(context.Context, *EmptyRequest) (*SubscriptionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSubscription not implemented")
+}
+func (*UnimplementedGandalfServer) SetSubscription(context.Context, *SetSubscriptionRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSubscription not implemented")
+}
+func (*UnimplementedGandalfServer) EmergencyHalt(context.Context, *EmergencyHaltRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EmergencyHalt not implemented")
+}
+func (*UnimplementedGandalfServer) EmergencyResume(context.Context, *EmptyRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EmergencyResume not implemented")
+}
+func (*UnimplementedGandalfServer) GetSymbolConfig(context.Context, *GetSymbolConfigRequest) (*SymbolConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSymbolConfig not implemented")
+}
+func (*UnimplementedGandalfServer) SetSymbolConfig(context.Context, *SetSymbolConfigRequest) (*SymbolConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSymbolConfig not implemented")
+}
+func (*UnimplementedGandalfServer) GetPredictionStats(context.Context, *PredictionStatsRequest) (*PredictionStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPredictionStats not implemented")
+}
+func (*UnimplementedGandalfServer) ScheduleStatusChange(context.Context, *ScheduleStatusChangeRequest) (*ScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleStatusChange not implemented")
 }
-func (*UnimplementedGandalfServer) GetSymbolBalances(context.Context, *EmptyRequest) (*SymbolBalancesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetSymbolBalances not implemented")
+func (*UnimplementedGandalfServer) ListSchedules(context.Context, *EmptyRequest) (*SchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
 }
-func (*UnimplementedGandalfServer) GetSymbolLimits(context.Context, *GetSymbolLimitsRequest) (*SymbolLimitsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetSymbolLimits not implemented")
+func (*UnimplementedGandalfServer) CancelSchedule(context.Context, *CancelScheduleRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelSchedule not implemented")
 }
-func (*UnimplementedGandalfServer) SetSymbolLimits(context.Context, *SetSymbolLimitsRequest) (*EmptyResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method SetSymbolLimits not implemented")
+func (*UnimplementedGandalfServer) ExportDeals(*ExportDealsRequest, Gandalf_ExportDealsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportDeals not implemented")
 }
-func (*UnimplementedGandalfServer) GetActiveDeals(context.Context, *DealsRequest) (*DealsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetActiveDeals not implemented")
+func (*UnimplementedGandalfServer) ListPendingApprovals(context.Context, *EmptyRequest) (*ApprovalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingApprovals not implemented")
 }
-func (*UnimplementedGandalfServer) GetPotentialDeals(context.Context, *DealsRequest) (*PotentialDealsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetPotentialDeals not implemented")
+func (*UnimplementedGandalfServer) Approve(context.Context, *ApprovalRequest) (*ApprovalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Approve not implemented")
 }
-func (*UnimplementedGandalfServer) CloseDeals(context.Context, *DealsRequest) (*EmptyResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CloseDeals not implemented")
+func (*UnimplementedGandalfServer) Reject(context.Context, *ApprovalRequest) (*ApprovalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reject not implemented")
 }
 
 func RegisterGandalfServer(s *grpc.Server, srv GandalfServer) {
@@ -1757,7 +5662,7 @@ func RegisterGandalfServer(s *grpc.Server, srv GandalfServer) {
 }
 
 func _Gandalf_GetTradingSymbols_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmptyRequest)
+	in := new(TradingSymbolsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -1769,7 +5674,7 @@ func _Gandalf_GetTradingSymbols_Handler(srv interface{}, ctx context.Context, de
 		FullMethod: "/gandalf.Gandalf/GetTradingSymbols",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GandalfServer).GetTradingSymbols(ctx, req.(*EmptyRequest))
+		return srv.(GandalfServer).GetTradingSymbols(ctx, req.(*TradingSymbolsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1865,7 +5770,7 @@ func _Gandalf_SymbolTradingResume_Handler(srv interface{}, ctx context.Context,
 }
 
 func _Gandalf_GetSymbolBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmptyRequest)
+	in := new(SymbolBalancesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -1877,7 +5782,25 @@ func _Gandalf_GetSymbolBalances_Handler(srv interface{}, ctx context.Context, de
 		FullMethod: "/gandalf.Gandalf/GetSymbolBalances",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GandalfServer).GetSymbolBalances(ctx, req.(*EmptyRequest))
+		return srv.(GandalfServer).GetSymbolBalances(ctx, req.(*SymbolBalancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gandalf_GetBalanceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BalanceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GandalfServer).GetBalanceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gandalf.Gandalf/GetBalanceHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GandalfServer).GetBalanceHistory(ctx, req.(*BalanceHistoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1972,6 +5895,279 @@ func _Gandalf_CloseDeals_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Gandalf_OpenPaperDeal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenPaperDealRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GandalfServer).OpenPaperDeal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gandalf.Gandalf/OpenPaperDeal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GandalfServer).OpenPaperDeal(ctx, req.(*OpenPaperDealRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gandalf_GetSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GandalfServer).GetSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gandalf.Gandalf/GetSubscription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GandalfServer).GetSubscription(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gandalf_SetSubscription_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSubscriptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GandalfServer).SetSubscription(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gandalf.Gandalf/SetSubscription",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GandalfServer).SetSubscription(ctx, req.(*SetSubscriptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gandalf_EmergencyHalt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmergencyHaltRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GandalfServer).EmergencyHalt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gandalf.Gandalf/EmergencyHalt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GandalfServer).EmergencyHalt(ctx, req.(*EmergencyHaltRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gandalf_EmergencyResume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GandalfServer).EmergencyResume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gandalf.Gandalf/EmergencyResume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GandalfServer).EmergencyResume(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gandalf_GetSymbolConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSymbolConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GandalfServer).GetSymbolConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gandalf.Gandalf/GetSymbolConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GandalfServer).GetSymbolConfig(ctx, req.(*GetSymbolConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gandalf_SetSymbolConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetSymbolConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GandalfServer).SetSymbolConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gandalf.Gandalf/SetSymbolConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GandalfServer).SetSymbolConfig(ctx, req.(*SetSymbolConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gandalf_GetPredictionStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PredictionStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GandalfServer).GetPredictionStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gandalf.Gandalf/GetPredictionStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GandalfServer).GetPredictionStats(ctx, req.(*PredictionStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gandalf_ScheduleStatusChange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleStatusChangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GandalfServer).ScheduleStatusChange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gandalf.Gandalf/ScheduleStatusChange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GandalfServer).ScheduleStatusChange(ctx, req.(*ScheduleStatusChangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gandalf_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GandalfServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gandalf.Gandalf/ListSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GandalfServer).ListSchedules(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gandalf_CancelSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GandalfServer).CancelSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gandalf.Gandalf/CancelSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GandalfServer).CancelSchedule(ctx, req.(*CancelScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gandalf_ExportDeals_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportDealsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GandalfServer).ExportDeals(m, &gandalfExportDealsServer{stream})
+}
+
+type Gandalf_ExportDealsServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type gandalfExportDealsServer struct {
+	grpc.ServerStream
+}
+
+func (x *gandalfExportDealsServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Gandalf_ListPendingApprovals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GandalfServer).ListPendingApprovals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gandalf.Gandalf/ListPendingApprovals",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GandalfServer).ListPendingApprovals(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gandalf_Approve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApprovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GandalfServer).Approve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gandalf.Gandalf/Approve",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GandalfServer).Approve(ctx, req.(*ApprovalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Gandalf_Reject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApprovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GandalfServer).Reject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gandalf.Gandalf/Reject",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GandalfServer).Reject(ctx, req.(*ApprovalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Gandalf_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "gandalf.Gandalf",
 	HandlerType: (*GandalfServer)(nil),
@@ -2004,6 +6200,10 @@ var _Gandalf_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetSymbolBalances",
 			Handler:    _Gandalf_GetSymbolBalances_Handler,
 		},
+		{
+			MethodName: "GetBalanceHistory",
+			Handler:    _Gandalf_GetBalanceHistory_Handler,
+		},
 		{
 			MethodName: "GetSymbolLimits",
 			Handler:    _Gandalf_GetSymbolLimits_Handler,
@@ -2024,7 +6224,69 @@ var _Gandalf_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CloseDeals",
 			Handler:    _Gandalf_CloseDeals_Handler,
 		},
+		{
+			MethodName: "OpenPaperDeal",
+			Handler:    _Gandalf_OpenPaperDeal_Handler,
+		},
+		{
+			MethodName: "GetSubscription",
+			Handler:    _Gandalf_GetSubscription_Handler,
+		},
+		{
+			MethodName: "SetSubscription",
+			Handler:    _Gandalf_SetSubscription_Handler,
+		},
+		{
+			MethodName: "EmergencyHalt",
+			Handler:    _Gandalf_EmergencyHalt_Handler,
+		},
+		{
+			MethodName: "EmergencyResume",
+			Handler:    _Gandalf_EmergencyResume_Handler,
+		},
+		{
+			MethodName: "GetSymbolConfig",
+			Handler:    _Gandalf_GetSymbolConfig_Handler,
+		},
+		{
+			MethodName: "SetSymbolConfig",
+			Handler:    _Gandalf_SetSymbolConfig_Handler,
+		},
+		{
+			MethodName: "GetPredictionStats",
+			Handler:    _Gandalf_GetPredictionStats_Handler,
+		},
+		{
+			MethodName: "ScheduleStatusChange",
+			Handler:    _Gandalf_ScheduleStatusChange_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _Gandalf_ListSchedules_Handler,
+		},
+		{
+			MethodName: "CancelSchedule",
+			Handler:    _Gandalf_CancelSchedule_Handler,
+		},
+		{
+			MethodName: "ListPendingApprovals",
+			Handler:    _Gandalf_ListPendingApprovals_Handler,
+		},
+		{
+			MethodName: "Approve",
+			Handler:    _Gandalf_Approve_Handler,
+		},
+		{
+			MethodName: "Reject",
+			Handler:    _Gandalf_Reject_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportDeals",
+			Handler:       _Gandalf_ExportDeals_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pb/service.proto",
 }
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ExcursionTracker samples the delta of open deals every interval to record
+// how far they went in both directions, which is what the predictions of the
+// deals are checked against when they close. Moves between samples are missed,
+// so the interval bounds the accuracy of the excursions.
+type ExcursionTracker struct {
+	logger   *zap.SugaredLogger
+	storage  *Storage
+	prices   PriceSource
+	interval time.Duration
+}
+
+func NewExcursionTracker(
+	logger *zap.SugaredLogger,
+	storage *Storage,
+	prices PriceSource,
+	interval time.Duration,
+) *ExcursionTracker {
+	return &ExcursionTracker{
+		logger:   logger,
+		storage:  storage,
+		prices:   prices,
+		interval: interval,
+	}
+}
+
+// Run samples the open deals every interval until ctx is done.
+func (t *ExcursionTracker) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := t.sample(ctx); err != nil {
+				t.logger.Errorf("cannot sample deal excursions: %v", err)
+			}
+		}
+	}
+}
+
+func (t *ExcursionTracker) sample(ctx context.Context) error {
+	prices := make(map[string]decimal.Decimal)
+	return t.storage.EachDeal(ctx, nil, time.Time{}, time.Time{}, func(deal *Deal) error {
+		delta := deal.DeltaPercent
+		if deal.OpenPrice.IsPositive() {
+			price, ok := prices[deal.Symbol]
+			if !ok {
+				var err error
+				if price, err = t.prices.GetPrice(ctx, deal.Symbol); err != nil {
+					t.logger.Warnf("cannot get price of %s for deal excursions: %v", deal.Symbol, err)
+				}
+				prices[deal.Symbol] = price
+			}
+			if price.IsPositive() {
+				delta = dealDeltaPercent(deal, price)
+			}
+		}
+
+		if !deal.Excursion.update(delta, deal.Prediction) {
+			return nil
+		}
+		// a deal changed or closed meanwhile is sampled again on the next tick
+		if err := t.storage.UpdateDealExcursion(ctx, deal); err != nil && status.Code(err) != codes.Aborted {
+			return err
+		}
+		return nil
+	})
+}
+
+// update widens the excursion by the delta, it returns false if nothing changed.
+func (e *DealExcursion) update(delta decimal.Decimal, prediction DealPrediction) bool {
+	changed := false
+	if delta.GreaterThan(e.Favorable) {
+		e.Favorable, changed = delta, true
+	}
+	if delta.LessThan(e.Adverse) {
+		e.Adverse, changed = delta, true
+	}
+
+	if e.FirstHit == "" {
+		stop, max := decimal.NewFromFloat32(prediction.Stop), decimal.NewFromFloat32(prediction.Max)
+		if prediction.Stop != 0 && delta.LessThanOrEqual(stop) {
+			e.FirstHit, changed = hitStop, true
+		} else if prediction.Max != 0 && delta.GreaterThanOrEqual(max) {
+			e.FirstHit, changed = hitMax, true
+		}
+	}
+
+	return changed
+}
+
+func dealDeltaPercent(deal *Deal, price decimal.Decimal) decimal.Decimal {
+	return price.Div(deal.OpenPrice).Sub(decimal.NewFromInt(1)).Mul(decimal.NewFromInt(100))
+}
+
+type predictionKey struct{ symbol, timeframe string }
+
+type predictionSums struct {
+	stop, max, adverse, favorable, result float64
+	stopHit, maxHit                       int32
+}
+
+// predictionStats aggregates the closed deals per symbol and timeframe, the
+// deals are added one by one so that a long history isn't loaded at once.
+type predictionStats struct {
+	stats  map[predictionKey]*pb.PredictionStats
+	totals map[predictionKey]*predictionSums
+}
+
+func newPredictionStats() *predictionStats {
+	return &predictionStats{
+		stats:  make(map[predictionKey]*pb.PredictionStats),
+		totals: make(map[predictionKey]*predictionSums),
+	}
+}
+
+func (p *predictionStats) add(deal *ClosedDeal) {
+	k := predictionKey{deal.Symbol, deal.Timeframe}
+	if p.stats[k] == nil {
+		p.stats[k] = &pb.PredictionStats{Symbol: deal.Symbol, Timeframe: deal.Timeframe}
+		p.totals[k] = &predictionSums{}
+	}
+	s, t := p.stats[k], p.totals[k]
+
+	s.Deals++
+	switch deal.Excursion.FirstHit {
+	case hitStop:
+		s.StopHitFirst++
+	case hitMax:
+		s.MaxHitFirst++
+	default:
+		s.NoneHit++
+	}
+
+	stop, max := float64(deal.Prediction.Stop), float64(deal.Prediction.Max)
+	adverse, favorable := deal.Excursion.Adverse.InexactFloat64(), deal.Excursion.Favorable.InexactFloat64()
+	if deal.Prediction.Stop != 0 && adverse <= stop {
+		t.stopHit++
+	}
+	if deal.Prediction.Max != 0 && favorable >= max {
+		t.maxHit++
+	}
+	t.stop += stop
+	t.max += max
+	t.adverse += adverse
+	t.favorable += favorable
+	t.result += deal.DeltaPercent.InexactFloat64()
+}
+
+func (p *predictionStats) result() []*pb.PredictionStats {
+	result := make([]*pb.PredictionStats, 0, len(p.stats))
+	for k, s := range p.stats {
+		t, n := p.totals[k], float64(s.Deals)
+		s.StopHitRatio = float64(t.stopHit) / n
+		s.MaxHitRatio = float64(t.maxHit) / n
+		s.AvgPredictedStop = t.stop / n
+		s.AvgPredictedMax = t.max / n
+		s.AvgMaxAdversePercent = t.adverse / n
+		s.AvgMaxFavorablePercent = t.favorable / n
+		s.AvgResultPercent = t.result / n
+		result = append(result, s)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Symbol != result[j].Symbol {
+			return result[i].Symbol < result[j].Symbol
+		}
+		return result[i].Timeframe < result[j].Timeframe
+	})
+	return result
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PriceSource provides the current price of a symbol in its quote currency.
+type PriceSource interface {
+	GetPrice(ctx context.Context, symbol string) (decimal.Decimal, error)
+}
+
+var (
+	errPriceNotFound = func(symbol string) error {
+		return status.Error(codes.Unavailable, fmt.Sprintf("no price for symbol '%s'", symbol))
+	}
+)
+
+// HuobiPriceSource reads the last trade price from the Huobi market API.
+type HuobiPriceSource struct {
+	baseUrl string
+	client  *http.Client
+}
+
+func NewHuobiPriceSource(baseUrl string) *HuobiPriceSource {
+	return &HuobiPriceSource{
+		baseUrl: strings.TrimRight(baseUrl, "/"),
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (s *HuobiPriceSource) GetPrice(ctx context.Context, symbol string) (decimal.Decimal, error) {
+	u := s.baseUrl + "/market/detail/merged?symbol=" + url.QueryEscape(symbol)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Status string `json:"status"`
+		ErrMsg string `json:"err-msg"`
+		Tick   struct {
+			Close decimal.Decimal `json:"close"`
+		} `json:"tick"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return decimal.Zero, err
+	}
+	if body.Status != "ok" {
+		return decimal.Zero, errors.New(fmt.Sprintf("huobi: %s", body.ErrMsg))
+	}
+
+	return body.Tick.Close, nil
+}
+
+// ReplayPriceSource replays prices recorded in a CSV file with
+// "timestamp,symbol,price" rows. Every call returns the next recorded price
+// of the symbol, the last one is repeated once the records are exhausted.
+type ReplayPriceSource struct {
+	mu     sync.Mutex
+	prices map[string][]decimal.Decimal
+	pos    map[string]int
+}
+
+func NewReplayPriceSource(r io.Reader) (*ReplayPriceSource, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 3
+
+	prices := make(map[string][]decimal.Decimal)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		symbol := strings.TrimSpace(record[1])
+		price, err := decimal.NewFromString(strings.TrimSpace(record[2]))
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("can't parse price for '%s': %v", symbol, err))
+		}
+
+		prices[symbol] = append(prices[symbol], price)
+	}
+
+	return &ReplayPriceSource{
+		prices: prices,
+		pos:    make(map[string]int),
+	}, nil
+}
+
+func NewReplayPriceSourceFromFile(path string) (*ReplayPriceSource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return NewReplayPriceSource(f)
+}
+
+func (s *ReplayPriceSource) GetPrice(_ context.Context, symbol string) (decimal.Decimal, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prices := s.prices[symbol]
+	if len(prices) == 0 {
+		return decimal.Zero, errPriceNotFound(symbol)
+	}
+
+	i := s.pos[symbol]
+	if i < len(prices)-1 {
+		s.pos[symbol] = i + 1
+	}
+
+	return prices[i], nil
+}
+
+const defaultQuoteCurrency = "usdt"
+
+// quoteCurrencies are the quote currencies symbols are traded against, longest
+// names first so that e.g. "husd" is not mistaken for "usd".
+var quoteCurrencies = []string{"usdt", "husd", "usdc", "btc", "eth", "trx", "ht"}
+
+func isQuoteCurrency(currency string) bool {
+	for _, quote := range quoteCurrencies {
+		if currency == quote {
+			return true
+		}
+	}
+	return false
+}
+
+// splitSymbol splits a symbol like "adausdt" into its base and quote currency.
+func splitSymbol(symbol string) (string, string) {
+	for _, quote := range quoteCurrencies {
+		if strings.HasSuffix(symbol, quote) && len(symbol) > len(quote) {
+			return strings.TrimSuffix(symbol, quote), quote
+		}
+	}
+	return symbol, ""
+}
+
+// ConvertAmount values an amount of the base currency of the symbol in the
+// given quote currency using current prices.
+func ConvertAmount(
+	ctx context.Context,
+	prices PriceSource,
+	symbol string,
+	amount decimal.Decimal,
+	quote string,
+) (decimal.Decimal, error) {
+	base, _ := splitSymbol(symbol)
+	if base == quote || amount.IsZero() {
+		return amount, nil
+	}
+
+	price, err := prices.GetPrice(ctx, base+quote)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return amount.Mul(price), nil
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	retryAfterHeader = "retry-after"
+
+	rateClassRead   = "read"
+	rateClassMutate = "mutate"
+
+	rateLimiterSweepInterval = time.Minute
+)
+
+// RateLimit is a token bucket refilled with Rate tokens per second up to Burst
+// tokens. A zero rate disables the limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+type rateBucketKey struct {
+	userId int64
+	class  string
+}
+
+type rateBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// RateLimiter keeps a token bucket per user and method class, read rpcs and
+// mutating rpcs are limited separately so that polling cannot starve changes.
+type RateLimiter struct {
+	mu        sync.Mutex
+	limits    map[string]RateLimit
+	buckets   map[rateBucketKey]*rateBucket
+	lastSweep time.Time
+	metrics   *Metrics
+}
+
+func NewRateLimiter(metrics *Metrics, read RateLimit, mutate RateLimit) *RateLimiter {
+	return &RateLimiter{
+		limits: map[string]RateLimit{
+			rateClassRead:   read,
+			rateClassMutate: mutate,
+		},
+		buckets:   make(map[rateBucketKey]*rateBucket),
+		lastSweep: time.Now(),
+		metrics:   metrics,
+	}
+}
+
+// UnaryServerInterceptor rejects calls over the limit of the user with
+// ResourceExhausted, the time until a token is available is sent in the
+// retry-after header and in the RetryInfo details of the status.
+func (l *RateLimiter) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := l.allow(ctx, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor limits streaming calls the same way, the user is
+// known once the request message of the stream is received.
+func (l *RateLimiter) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		received := false
+		return handler(srv, &wrappedStream{
+			ServerStream: ss,
+			ctx:          ss.Context(),
+			onRecv: func(m interface{}) error {
+				if received {
+					return nil
+				}
+				received = true
+				return l.allow(ss.Context(), info.FullMethod, m)
+			},
+		})
+	}
+}
+
+func (l *RateLimiter) allow(ctx context.Context, fullMethod string, req interface{}) error {
+	class := rateClassMutate
+	if isReadOnlyRpc(fullMethod) {
+		class = rateClassRead
+	}
+
+	var userId int64
+	if r, ok := req.(userRequest); ok {
+		userId = r.GetUserId()
+	}
+
+	wait := l.take(rateBucketKey{userId, class}, time.Now())
+	if wait == 0 {
+		return nil
+	}
+
+	l.metrics.observeRateLimited(fullMethod, class)
+
+	seconds := int64(math.Ceil(wait.Seconds()))
+	setResponseHeader(ctx, retryAfterHeader, strconv.FormatInt(seconds, 10))
+
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("rate limit of %s requests exceeded, retry after %ds", class, seconds))
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// take takes a token from the bucket of the key, it returns zero on success
+// and the time until the next token otherwise.
+func (l *RateLimiter) take(key rateBucketKey, now time.Time) time.Duration {
+	limit := l.limits[key.class]
+	if limit.Rate <= 0 {
+		return 0
+	}
+	burst := math.Max(float64(limit.Burst), 1)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= rateLimiterSweepInterval {
+		l.sweep(now)
+	}
+
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &rateBucket{tokens: burst, updated: now}
+		l.buckets[key] = bucket
+	}
+
+	bucket.tokens = math.Min(burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*limit.Rate)
+	bucket.updated = now
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - bucket.tokens) / limit.Rate * float64(time.Second))
+}
+
+// sweep forgets the buckets that have been refilled completely, a new bucket
+// starts full, so nothing changes for their users.
+func (l *RateLimiter) sweep(now time.Time) {
+	for key, bucket := range l.buckets {
+		limit := l.limits[key.class]
+		if bucket.tokens+now.Sub(bucket.updated).Seconds()*limit.Rate >= float64(limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Scheduler runs the due schedules every interval. A run is claimed in Mongo
+// before the status is changed, so every run happens once when several
+// instances are running. Runs missed while the service was down are made once
+// on start and the cron schedules continue from the current time.
+type Scheduler struct {
+	logger   *zap.SugaredLogger
+	storage  *Storage
+	server   *Server
+	interval time.Duration
+}
+
+func NewScheduler(
+	logger *zap.SugaredLogger,
+	storage *Storage,
+	server *Server,
+	interval time.Duration,
+) *Scheduler {
+	return &Scheduler{
+		logger:   logger,
+		storage:  storage,
+		server:   server,
+		interval: interval,
+	}
+}
+
+// newScheduleId returns a random id, so that schedules created at once, or by
+// several instances, don't collide.
+func newScheduleId() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return "s-" + hex.EncodeToString(id)
+}
+
+// Run runs the due schedules every interval until ctx is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.runDue(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runDue(ctx)
+		}
+	}
+}
+
+func (s *Scheduler) runDue(ctx context.Context) {
+	now := time.Now()
+	schedules, err := s.storage.GetDueSchedules(ctx, now)
+	if err != nil {
+		s.logger.Errorf("cannot get due schedules: %v", err)
+		return
+	}
+
+	for _, schedule := range schedules {
+		if err := s.run(ctx, schedule, now); err != nil {
+			s.logger.Errorf("cannot run schedule %s: %v", schedule.Id, err)
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, schedule *Schedule, now time.Time) error {
+	var nextRunAt *time.Time
+	if schedule.Cron != "" {
+		cron, err := parseCron(schedule.Cron)
+		if err != nil {
+			return err
+		}
+		if next := cron.Next(now.UTC()); !next.IsZero() {
+			nextRunAt = &next
+		}
+	}
+
+	claimed, err := s.storage.ClaimSchedule(ctx, schedule, nextRunAt)
+	if err != nil || !claimed {
+		return err
+	}
+
+	runErr := ""
+	if _, err := s.server.setSymbolStatus(ctx, schedule.Symbol, schedule.Status); err != nil {
+		runErr = err.Error()
+		s.logger.Warnf("schedule %s cannot set %s %s: %v", schedule.Id, schedule.Symbol, schedule.Status, err)
+	} else {
+		s.logger.Infof("schedule %s set %s %s", schedule.Id, schedule.Symbol, schedule.Status)
+	}
+
+	return s.storage.SetScheduleResult(ctx, schedule.Id, now, runErr)
+}
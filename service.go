@@ -2,11 +2,15 @@ package main
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	pb "github.com/mikevel2955/gandalf/pb"
+	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -15,17 +19,21 @@ type Server struct {
 	userOperators []int64
 	userViewers   []int64
 	storage       *Storage
+	prices        PriceSource
+	paper         *PaperTrader
+	notifier      *Notifier
 }
 
 var (
-	errUserNotOperator = errors.New("you are not authorized to perform this operation")
-	errUserNotViewer   = errors.New("you are not authorized to view this data")
+	errUserNotOperator = status.Error(codes.PermissionDenied, "you are not authorized to perform this operation")
+	errUserNotViewer   = status.Error(codes.PermissionDenied, "you are not authorized to view this data")
 	errSymbolNotFound  = func(symbol string) error {
-		return errors.New(fmt.Sprintf("unknown symbol '%s'", symbol))
+		return status.Error(codes.NotFound, fmt.Sprintf("unknown symbol '%s'", symbol))
 	}
 	errDealNotFound = func(dealId string) error {
-		return errors.New(fmt.Sprintf("unknown deal '%s'", dealId))
+		return status.Error(codes.NotFound, fmt.Sprintf("unknown deal '%s'", dealId))
 	}
+	errTradingHalted = status.Error(codes.FailedPrecondition, "trading is halted")
 )
 
 func NewServer(
@@ -33,21 +41,50 @@ func NewServer(
 	userOperators []int64,
 	userViewers []int64,
 	storage *Storage,
+	prices PriceSource,
+	paper *PaperTrader,
+	notifier *Notifier,
 ) *Server {
 	return &Server{
 		logger:        logger,
 		userOperators: userOperators,
 		userViewers:   userViewers,
 		storage:       storage,
+		prices:        prices,
+		paper:         paper,
+		notifier:      notifier,
 	}
 }
 
-func (s *Server) GetTradingSymbols(ctx context.Context, req *pb.EmptyRequest) (*pb.TradingSymbolsResponse, error) {
+func (s *Server) GetTradingSymbols(ctx context.Context, req *pb.TradingSymbolsRequest) (*pb.TradingSymbolsResponse, error) {
 	if err := s.checkUserViewer(req.UserId); err != nil {
 		return nil, err
 	}
 
-	tradingSymbols, err := s.storage.GetTradingSymbols(ctx)
+	pageSize, err := pageSizeFromPb(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := decodePageToken(req.PageToken, "symbol", false)
+	if err != nil {
+		return nil, err
+	}
+	var after string
+	if cursor != nil {
+		after = cursor.Id
+	}
+
+	// one more than the page to know whether there is a next page
+	tradingSymbols, err := s.storage.FindTradingSymbols(ctx, after, pageSize+1)
+	if err != nil {
+		return nil, err
+	}
+	var nextPageToken string
+	if int64(len(tradingSymbols)) > pageSize {
+		tradingSymbols = tradingSymbols[:pageSize]
+		nextPageToken = encodePageToken(&pageCursor{Sort: "symbol", Id: tradingSymbols[pageSize-1].Symbol})
+	}
+	halt, err := s.storage.GetHalt(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -55,13 +92,19 @@ func (s *Server) GetTradingSymbols(ctx context.Context, req *pb.EmptyRequest) (*
 	var symbols []*pb.TradingSymbol
 	for _, symbol := range tradingSymbols {
 		symbols = append(symbols, &pb.TradingSymbol{
-			Symbol: symbol.Symbol,
-			Status: symbol.Status,
+			Symbol:        symbol.Symbol,
+			Status:        symbol.Status,
+			Paper:         symbol.Paper,
+			SuspendReason: symbol.SuspendReason,
+			Config:        symbolConfigToPb(&symbol.Config),
+			Version:       symbol.Version,
 		})
 	}
 
 	return &pb.TradingSymbolsResponse{
-		Symbols: symbols,
+		Symbols:       symbols,
+		Halted:        halt != nil,
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
@@ -70,18 +113,34 @@ func (s *Server) SymbolTradingPrepare(ctx context.Context, req *pb.SymbolRequest
 		return nil, err
 	}
 
-	tradingSymbol, err := s.storage.GetTradingSymbol(ctx, req.Symbol)
+	config, err := mergeSymbolConfig(defaultSymbolConfig(), req.Config)
 	if err != nil {
 		return nil, err
 	}
-	if tradingSymbol != nil {
-		return nil, errors.New(fmt.Sprintf("%s is already in trading", req.Symbol))
+
+	// a symbol prepared again continues the history of its previous trading
+	history, err := s.storage.GetSymbolConfigHistory(ctx, req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	version := int32(1)
+	if len(history) > 0 {
+		version = history[0].Version + 1
 	}
+	config = newSymbolConfigVersion(config, version, req.UserId)
 
-	tradingSymbol = &TradingSymbol{req.Symbol, pb.TradingSymbol_PREPARING, 0, 100}
-	if err := s.storage.SaveTradingSymbol(ctx, tradingSymbol); err != nil {
+	tradingSymbol := &TradingSymbol{req.Symbol, pb.TradingSymbol_PREPARING, decimal.Zero, decimal.NewFromInt(100), req.Paper, decimal.Zero, "", time.Time{}, config, 0}
+	created, err := s.storage.CreateTradingSymbol(ctx, tradingSymbol)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		return nil, status.Error(codes.AlreadyExists, fmt.Sprintf("%s is already in trading", req.Symbol))
+	}
+	if _, err := s.storage.AddSymbolConfig(ctx, req.Symbol, config); err != nil {
 		return nil, err
 	}
+	s.publishStatusChanged(tradingSymbol.Symbol, tradingSymbol.Status)
 
 	return &pb.EmptyResponse{}, nil
 }
@@ -103,6 +162,11 @@ func (s *Server) SymbolTradingStop(ctx context.Context, req *pb.SymbolRequest) (
 	if err != nil {
 		return nil, err
 	}
+	s.notifier.Publish(Event{
+		Type:    EventStatusChanged,
+		Symbol:  req.Symbol,
+		Message: fmt.Sprintf("%s trading is stopped", req.Symbol),
+	})
 
 	return &pb.EmptyResponse{}, nil
 }
@@ -123,34 +187,108 @@ func (s *Server) SymbolTradingResume(ctx context.Context, req *pb.SymbolRequest)
 	return s.setSymbolStatus(ctx, req.Symbol, pb.TradingSymbol_ACTIVE)
 }
 
-func (s *Server) GetSymbolBalances(ctx context.Context, req *pb.EmptyRequest) (*pb.SymbolBalancesResponse, error) {
+func (s *Server) GetSymbolBalances(ctx context.Context, req *pb.SymbolBalancesRequest) (*pb.SymbolBalancesResponse, error) {
 	if err := s.checkUserViewer(req.UserId); err != nil {
 		return nil, err
 	}
 
+	quote := strings.ToLower(req.QuoteCurrency)
+	if quote == "" {
+		quote = defaultQuoteCurrency
+	}
+	if !isQuoteCurrency(quote) {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unsupported quote currency '%s'", req.QuoteCurrency))
+	}
+
 	tradingSymbols, err := s.storage.GetTradingSymbols(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var balances []*pb.SymbolBalance
-	var total float32
+	var balances, paperBalances []*pb.SymbolBalance
+	total, paperTotal := decimal.Zero, decimal.Zero
 
 	for _, symbol := range tradingSymbols {
-		balances = append(balances, &pb.SymbolBalance{
-			Symbol: symbol.Symbol,
-			Amount: symbol.Balance,
-		})
-		total += symbol.Balance
+		balance := &pb.SymbolBalance{
+			Symbol:        symbol.Symbol,
+			Amount:        decimalToFloat(symbol.Balance),
+			AmountDecimal: symbol.Balance.String(),
+		}
+
+		// a symbol without a price keeps its quote value empty and out of the
+		// totals instead of failing every balance
+		value, err := ConvertAmount(ctx, s.prices, symbol.Symbol, symbol.Balance, quote)
+		if err != nil {
+			loggerFromContext(ctx, s.logger).Warnf("cannot value the balance of %s in %s: %v", symbol.Symbol, quote, err)
+			value = decimal.Zero
+		} else {
+			balance.QuoteValue = decimalToFloat(value)
+			balance.QuoteValueDecimal = value.String()
+		}
+
+		if symbol.Paper {
+			paperBalances = append(paperBalances, balance)
+			paperTotal = paperTotal.Add(value)
+			continue
+		}
+		balances = append(balances, balance)
+		total = total.Add(value)
 	}
 
-	balances = append(balances, &pb.SymbolBalance{
-		Symbol: "usd",
-		Amount: total,
+	return &pb.SymbolBalancesResponse{
+		Balances:          balances,
+		PaperBalances:     paperBalances,
+		QuoteCurrency:     quote,
+		Total:             decimalToFloat(total),
+		PaperTotal:        decimalToFloat(paperTotal),
+		TotalDecimal:      total.String(),
+		PaperTotalDecimal: paperTotal.String(),
+	}, nil
+}
+
+// defaultBalanceHistory is the range of GetBalanceHistory without dateFrom.
+const defaultBalanceHistory = 7 * 24 * time.Hour
+
+func (s *Server) GetBalanceHistory(ctx context.Context, req *pb.BalanceHistoryRequest) (*pb.BalanceHistoryResponse, error) {
+	if err := s.checkUserViewer(req.UserId); err != nil {
+		return nil, err
+	}
+
+	quote := strings.ToLower(req.QuoteCurrency)
+	if quote == "" {
+		quote = defaultQuoteCurrency
+	}
+	location, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unknown timezone %s", req.Timezone))
+	}
+
+	to := time.Now()
+	if req.DateTo != nil {
+		to = req.DateTo.AsTime()
+	}
+	from := to.Add(-defaultBalanceHistory)
+	if req.DateFrom != nil {
+		from = req.DateFrom.AsTime()
+	}
+	if !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "dateFrom must be before dateTo")
+	}
+
+	downsampler := newBalanceDownsampler(req.Interval, location)
+	err = s.storage.EachBalanceBucket(ctx, req.Symbols, quote, from, to, func(bucket *BalanceBucket) error {
+		downsampler.add(bucket)
+		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	return &pb.SymbolBalancesResponse{
-		Balances: balances,
+	histories, totals := downsampler.history()
+	return &pb.BalanceHistoryResponse{
+		Symbols:       histories,
+		Totals:        totals,
+		QuoteCurrency: quote,
 	}, nil
 }
 
@@ -168,8 +306,10 @@ func (s *Server) GetSymbolLimits(ctx context.Context, req *pb.GetSymbolLimitsReq
 
 	for _, symbol := range tradingSymbols {
 		limits = append(limits, &pb.SymbolLimit{
-			Symbol: symbol.Symbol,
-			Limit:  symbol.Limit,
+			Symbol:             symbol.Symbol,
+			Limit:              decimalToFloat(symbol.Limit),
+			LimitDecimal:       symbol.Limit.String(),
+			MaxDrawdownDecimal: symbol.MaxDrawdown.String(),
 		})
 	}
 
@@ -184,6 +324,11 @@ func (s *Server) SetSymbolLimits(ctx context.Context, req *pb.SetSymbolLimitsReq
 	}
 
 	for _, limit := range req.Limits {
+		value, err := decimalFromPb(limit.LimitDecimal, limit.Limit)
+		if err != nil {
+			return nil, err
+		}
+
 		tradingSymbol, err := s.storage.GetTradingSymbol(ctx, limit.Symbol)
 		if err != nil {
 			return nil, err
@@ -192,10 +337,21 @@ func (s *Server) SetSymbolLimits(ctx context.Context, req *pb.SetSymbolLimitsReq
 			return nil, errSymbolNotFound(limit.Symbol)
 		}
 
-		tradingSymbol.Limit = limit.Limit
-		if err := s.storage.SaveTradingSymbol(ctx, tradingSymbol); err != nil {
+		tradingSymbol.Limit = value
+		if limit.MaxDrawdownDecimal != "" {
+			maxDrawdown, err := decimalFromPb(limit.MaxDrawdownDecimal, 0)
+			if err != nil {
+				return nil, err
+			}
+			if maxDrawdown.IsNegative() {
+				return nil, status.Error(codes.InvalidArgument, "max drawdown must not be negative")
+			}
+			tradingSymbol.MaxDrawdown = maxDrawdown
+		}
+		if err := s.storage.UpdateTradingSymbol(ctx, tradingSymbol, "limit", "max_drawdown"); err != nil {
 			return nil, err
 		}
+		s.checkSymbolLimit(ctx, tradingSymbol)
 	}
 
 	return &pb.EmptyResponse{}, nil
@@ -206,35 +362,67 @@ func (s *Server) GetActiveDeals(ctx context.Context, req *pb.DealsRequest) (*pb.
 		return nil, err
 	}
 
-	if req.All {
-		deals, err := s.storage.GetDeals(ctx)
-		if err != nil {
+	if !req.All && len(req.Symbols) == 0 && len(req.DealIds) == 0 && req.DateFrom == nil && req.DateTo == nil {
+		return nil, status.Error(codes.InvalidArgument, "set all or filter by symbols, deal ids or dates")
+	}
+
+	sortField, ok := dealSortFields[req.SortBy]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unknown sort field %v", req.SortBy))
+	}
+	pageSize, err := pageSizeFromPb(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := decodePageToken(req.PageToken, sortField, req.Descending)
+	if err != nil {
+		return nil, err
+	}
+
+	// one more than the page to know whether there is a next page
+	query := &DealQuery{SortField: sortField, Descending: req.Descending, Limit: pageSize + 1}
+	if !req.All {
+		query.Symbols = req.Symbols
+		query.DealIds = req.DealIds
+		if req.DateFrom != nil {
+			query.From = req.DateFrom.AsTime()
+		}
+		if req.DateTo != nil {
+			query.To = req.DateTo.AsTime()
+		}
+	}
+	if cursor != nil {
+		if query.AfterValue, err = parseDealSortValue(cursor.Value, sortField); err != nil {
 			return nil, err
 		}
+		query.AfterId = cursor.Id
+	}
 
-		var activeDeals []*pb.Deal
-		for _, deal := range deals {
-			activeDeals = append(activeDeals, &pb.Deal{
-				DealId:         deal.Id,
-				Symbol:         deal.Symbol,
-				CreatedAt:      timestamppb.New(deal.CreatedAt),
-				Amount:         deal.Amount,
-				AmountCurrency: deal.AmountCurrency,
-				DeltaAmount:    deal.DeltaAmount,
-				DeltaPercent:   deal.DeltaPercent,
-				Prediction: &pb.Deal_DealPrediction{
-					Stop: deal.Prediction.Stop,
-					Max:  deal.Prediction.Max,
-				},
-			})
-		}
+	deals, err := s.storage.FindDeals(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	var nextPageToken string
+	if int64(len(deals)) > pageSize {
+		deals = deals[:pageSize]
+		last := deals[pageSize-1]
+		nextPageToken = encodePageToken(&pageCursor{
+			Sort:       sortField,
+			Descending: req.Descending,
+			Value:      dealSortValue(last, sortField),
+			Id:         last.Id,
+		})
+	}
 
-		return &pb.DealsResponse{
-			Deals: activeDeals,
-		}, nil
+	var activeDeals []*pb.Deal
+	for _, deal := range deals {
+		activeDeals = append(activeDeals, dealToPb(deal))
 	}
 
-	panic("implement me")
+	return &pb.DealsResponse{
+		Deals:         activeDeals,
+		NextPageToken: nextPageToken,
+	}, nil
 }
 
 func (s *Server) GetPotentialDeals(_ context.Context, req *pb.DealsRequest) (*pb.PotentialDealsResponse, error) {
@@ -242,7 +430,7 @@ func (s *Server) GetPotentialDeals(_ context.Context, req *pb.DealsRequest) (*pb
 		return nil, err
 	}
 
-	panic("implement me")
+	return nil, status.Error(codes.Unimplemented, "potential deals are not implemented yet")
 }
 
 func (s *Server) CloseDeals(ctx context.Context, req *pb.DealsRequest) (*pb.EmptyResponse, error) {
@@ -251,15 +439,11 @@ func (s *Server) CloseDeals(ctx context.Context, req *pb.DealsRequest) (*pb.Empt
 	}
 
 	if req.All {
-		deals, err := s.storage.GetDeals(ctx)
-		if err != nil {
+		if err := s.storage.EachDeal(ctx, nil, time.Time{}, time.Time{}, func(deal *Deal) error {
+			return s.closeDeal(ctx, deal)
+		}); err != nil {
 			return nil, err
 		}
-		for _, deal := range deals {
-			if err := s.storage.DeleteDeal(ctx, deal.Id); err != nil {
-				return nil, err
-			}
-		}
 		return &pb.EmptyResponse{}, nil
 	}
 
@@ -273,14 +457,508 @@ func (s *Server) CloseDeals(ctx context.Context, req *pb.DealsRequest) (*pb.Empt
 			return nil, errDealNotFound(dealId)
 		}
 
-		if err := s.storage.DeleteDeal(ctx, deal.Id); err != nil {
+		if err := s.closeDeal(ctx, deal); err != nil {
+			return nil, err
+		}
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+func (s *Server) OpenPaperDeal(ctx context.Context, req *pb.OpenPaperDealRequest) (*pb.DealResponse, error) {
+	if err := s.checkUserOperator(req.UserId); err != nil {
+		return nil, err
+	}
+
+	var prediction DealPrediction
+	if req.Prediction != nil {
+		prediction = DealPrediction{req.Prediction.Stop, req.Prediction.Max}
+	}
+
+	amountCurrency, err := decimalFromPb(req.AmountCurrencyDecimal, req.AmountCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	deal, err := s.paper.OpenDeal(ctx, req.Symbol, amountCurrency, prediction)
+	if err != nil {
+		return nil, err
+	}
+	s.notifier.Publish(Event{
+		Type:    EventDealOpened,
+		Symbol:  deal.Symbol,
+		Message: fmt.Sprintf("deal %s opened on %s for %s", deal.Id, deal.Symbol, deal.AmountCurrency),
+		Data: map[string]string{
+			"deal_id":    deal.Id,
+			"amount":     deal.Amount.String(),
+			"open_price": deal.OpenPrice.String(),
+		},
+	})
+
+	tradingSymbol, err := s.storage.GetTradingSymbol(ctx, deal.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	if tradingSymbol != nil {
+		s.checkSymbolLimit(ctx, tradingSymbol)
+	}
+
+	return &pb.DealResponse{
+		Deal: dealToPb(deal),
+	}, nil
+}
+
+func (s *Server) GetSubscription(ctx context.Context, req *pb.EmptyRequest) (*pb.SubscriptionResponse, error) {
+	if err := s.checkUserViewer(req.UserId); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.storage.GetSubscription(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if subscription == nil {
+		subscription = &Subscription{UserId: req.UserId}
+	}
+
+	return &pb.SubscriptionResponse{
+		Subscription: subscriptionToPb(subscription),
+	}, nil
+}
+
+func (s *Server) SetSubscription(ctx context.Context, req *pb.SetSubscriptionRequest) (*pb.EmptyResponse, error) {
+	if err := s.checkUserViewer(req.UserId); err != nil {
+		return nil, err
+	}
+
+	subscription := &Subscription{UserId: req.UserId}
+	for _, event := range req.Subscription.GetEvents() {
+		subscription.Events = append(subscription.Events, EventType(event))
+	}
+	subscription.Symbols = req.Subscription.GetSymbols()
+	for _, channel := range req.Subscription.GetChannels() {
+		subscription.Channels = append(subscription.Channels, SubscriptionChannel{channel.Sink, channel.Target})
+	}
+
+	operator := s.checkUserOperator(req.UserId) == nil
+	if err := s.notifier.ValidateSubscription(subscription, operator); err != nil {
+		return nil, err
+	}
+	if err := s.storage.SaveSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// EmergencyHalt suspends every symbol and blocks new deals and activations
+// until EmergencyResume. The halt is stored before the symbols are suspended,
+// so trading stays halted if the call is interrupted or the service restarts.
+func (s *Server) EmergencyHalt(ctx context.Context, req *pb.EmergencyHaltRequest) (*pb.EmptyResponse, error) {
+	if err := s.checkUserOperator(req.UserId); err != nil {
+		return nil, err
+	}
+
+	if err := s.halt(ctx, req.UserId, req.Reason, req.CloseDeals); err != nil {
+		return nil, err
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// EmergencyResume restores the statuses the symbols had before the halt. Symbols
+// changed or added during the halt are left as they are. The circuit breaker
+// counts only the losses after the resume.
+func (s *Server) EmergencyResume(ctx context.Context, req *pb.EmptyRequest) (*pb.EmptyResponse, error) {
+	if err := s.checkUserOperator(req.UserId); err != nil {
+		return nil, err
+	}
+
+	halt, err := s.storage.GetHalt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if halt == nil {
+		return nil, status.Error(codes.FailedPrecondition, "trading is not halted")
+	}
+
+	tradingSymbols, err := s.storage.GetTradingSymbols(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resumedAt := time.Now()
+	for _, tradingSymbol := range tradingSymbols {
+		prior, ok := halt.PriorStatuses[tradingSymbol.Symbol]
+		if !ok || tradingSymbol.Status != pb.TradingSymbol_SUSPENDED || prior == tradingSymbol.Status {
+			continue
+		}
+
+		tradingSymbol.Status = prior
+		tradingSymbol.SuspendReason = ""
+		tradingSymbol.ResumedAt = resumedAt
+		if err := s.storage.UpdateTradingSymbol(ctx, tradingSymbol, "status", "suspend_reason", "resumed_at"); err != nil {
+			return nil, err
+		}
+		s.publishStatusChanged(tradingSymbol.Symbol, prior)
+	}
+
+	// the resume is stored first, so that the circuit breaker doesn't halt
+	// again for the losses the operator has seen
+	if err := s.storage.SaveHaltResume(ctx, &HaltResume{UserId: req.UserId, ResumedAt: resumedAt}); err != nil {
+		return nil, err
+	}
+	if err := s.storage.DeleteHalt(ctx); err != nil {
+		return nil, err
+	}
+
+	loggerFromContext(ctx, s.logger).Warnf("trading resumed by %d", req.UserId)
+	s.notifier.Publish(Event{
+		Type:    EventStatusChanged,
+		Message: "trading is resumed",
+		Data:    map[string]string{"status": "RESUMED"},
+	})
+
+	return &pb.EmptyResponse{}, nil
+}
+
+func (s *Server) GetSymbolConfig(ctx context.Context, req *pb.GetSymbolConfigRequest) (*pb.SymbolConfigResponse, error) {
+	if err := s.checkUserViewer(req.UserId); err != nil {
+		return nil, err
+	}
+
+	tradingSymbol, err := s.storage.GetTradingSymbol(ctx, req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	if tradingSymbol == nil {
+		return nil, errSymbolNotFound(req.Symbol)
+	}
+
+	resp := &pb.SymbolConfigResponse{
+		Symbol: tradingSymbol.Symbol,
+		Config: symbolConfigToPb(&tradingSymbol.Config),
+	}
+	if req.History {
+		history, err := s.storage.GetSymbolConfigHistory(ctx, req.Symbol)
+		if err != nil {
 			return nil, err
 		}
+		for _, config := range history {
+			if config.Version < tradingSymbol.Config.Version {
+				resp.History = append(resp.History, symbolConfigToPb(config))
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *Server) SetSymbolConfig(ctx context.Context, req *pb.SetSymbolConfigRequest) (*pb.SymbolConfigResponse, error) {
+	if err := s.checkUserOperator(req.UserId); err != nil {
+		return nil, err
+	}
+
+	tradingSymbol, err := s.storage.GetTradingSymbol(ctx, req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	if tradingSymbol == nil {
+		return nil, errSymbolNotFound(req.Symbol)
+	}
+
+	current := tradingSymbol.Config.Version
+	if req.ExpectedVersion != 0 && req.ExpectedVersion != current {
+		return nil, status.Error(codes.Aborted, fmt.Sprintf("config of %s is at version %d", req.Symbol, current))
+	}
+
+	config, err := mergeSymbolConfig(tradingSymbol.Config, req.Config)
+	if err != nil {
+		return nil, err
+	}
+	config = newSymbolConfigVersion(config, current+1, req.UserId)
+
+	tradingSymbol.Config = config
+	if err := s.storage.UpdateTradingSymbol(ctx, tradingSymbol, "config"); err != nil {
+		return nil, err
+	}
+	if _, err := s.storage.AddSymbolConfig(ctx, req.Symbol, config); err != nil {
+		return nil, err
+	}
+
+	return &pb.SymbolConfigResponse{
+		Symbol: tradingSymbol.Symbol,
+		Config: symbolConfigToPb(&config),
+	}, nil
+}
+
+func (s *Server) GetPredictionStats(ctx context.Context, req *pb.PredictionStatsRequest) (*pb.PredictionStatsResponse, error) {
+	if err := s.checkUserViewer(req.UserId); err != nil {
+		return nil, err
+	}
+
+	var from, to time.Time
+	if req.DateFrom != nil {
+		from = req.DateFrom.AsTime()
+	}
+	if req.DateTo != nil {
+		to = req.DateTo.AsTime()
+	}
+
+	stats := newPredictionStats()
+	err := s.storage.EachClosedDeal(ctx, req.Symbols, from, to, func(deal *ClosedDeal) error {
+		stats.add(deal)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.PredictionStatsResponse{
+		Stats: stats.result(),
+	}, nil
+}
+
+func (s *Server) ScheduleStatusChange(ctx context.Context, req *pb.ScheduleStatusChangeRequest) (*pb.ScheduleResponse, error) {
+	if err := s.checkUserOperator(req.UserId); err != nil {
+		return nil, err
+	}
+
+	if req.Status != pb.TradingSymbol_ACTIVE && req.Status != pb.TradingSymbol_SUSPENDED {
+		return nil, status.Error(codes.InvalidArgument, "only ACTIVE and SUSPENDED can be scheduled")
+	}
+	if (req.RunAt == nil) == (req.Cron == "") {
+		return nil, status.Error(codes.InvalidArgument, "either runAt or cron must be set")
+	}
+
+	tradingSymbol, err := s.storage.GetTradingSymbol(ctx, req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	if tradingSymbol == nil {
+		return nil, errSymbolNotFound(req.Symbol)
+	}
+
+	now := time.Now()
+	var nextRunAt time.Time
+	if req.Cron != "" {
+		cron, err := parseCron(req.Cron)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if nextRunAt = cron.Next(now.UTC()); nextRunAt.IsZero() {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("cron '%s' never runs", req.Cron))
+		}
+	} else {
+		if nextRunAt = req.RunAt.AsTime(); !nextRunAt.After(now) {
+			return nil, status.Error(codes.InvalidArgument, "runAt must be in the future")
+		}
+	}
+
+	schedule := &Schedule{
+		Id:        newScheduleId(),
+		Symbol:    req.Symbol,
+		Status:    req.Status,
+		Cron:      req.Cron,
+		NextRunAt: &nextRunAt,
+		CreatedBy: req.UserId,
+		CreatedAt: now,
+	}
+	if err := s.storage.SaveSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	return &pb.ScheduleResponse{
+		Schedule: scheduleToPb(schedule),
+	}, nil
+}
+
+func (s *Server) ListSchedules(ctx context.Context, req *pb.EmptyRequest) (*pb.SchedulesResponse, error) {
+	if err := s.checkUserViewer(req.UserId); err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.storage.GetSchedules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*pb.Schedule
+	for _, schedule := range schedules {
+		result = append(result, scheduleToPb(schedule))
+	}
+
+	return &pb.SchedulesResponse{
+		Schedules: result,
+	}, nil
+}
+
+func (s *Server) CancelSchedule(ctx context.Context, req *pb.CancelScheduleRequest) (*pb.EmptyResponse, error) {
+	if err := s.checkUserOperator(req.UserId); err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.storage.DeleteSchedule(ctx, req.ScheduleId)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("unknown schedule '%s'", req.ScheduleId))
 	}
 
 	return &pb.EmptyResponse{}, nil
 }
 
+// ExportDeals streams the open deals created and the deals closed within the
+// range, followed by the last balance snapshots of every balance bucket, as a
+// csv or xlsx file.
+func (s *Server) ExportDeals(req *pb.ExportDealsRequest, stream pb.Gandalf_ExportDealsServer) error {
+	if err := s.checkUserViewer(req.UserId); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+	columns, err := exportColumnsByName(req.Columns)
+	if err != nil {
+		return err
+	}
+	location, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("unknown timezone %s", req.Timezone))
+	}
+
+	var from time.Time
+	if req.DateFrom != nil {
+		from = req.DateFrom.AsTime()
+	}
+	to := time.Now()
+	if req.DateTo != nil {
+		to = req.DateTo.AsTime()
+	}
+	if !from.Before(to) {
+		return status.Error(codes.InvalidArgument, "dateFrom must be before dateTo")
+	}
+
+	extension, contentType := exportFormatFile(req.Format)
+	fileName := fmt.Sprintf("deals-%s.%s", to.In(location).Format("20060102"), extension)
+	if !from.IsZero() {
+		fileName = fmt.Sprintf("deals-%s-%s.%s", from.In(location).Format("20060102"), to.In(location).Format("20060102"), extension)
+	}
+
+	out := newExportChunkWriter(stream, fileName, contentType)
+	writer, err := newExportWriter(req.Format, out, columns)
+	if err != nil {
+		return err
+	}
+
+	err = s.storage.EachDeal(ctx, req.Symbols, from, to, func(deal *Deal) error {
+		return writer.WriteRow(dealExportRow(deal, time.Time{}, location))
+	})
+	if err != nil {
+		return err
+	}
+	err = s.storage.EachClosedDeal(ctx, req.Symbols, from, to, func(deal *ClosedDeal) error {
+		return writer.WriteRow(dealExportRow(&deal.Deal, deal.ClosedAt, location))
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.storage.EachBalanceBucket(ctx, req.Symbols, "", from, to, func(bucket *BalanceBucket) error {
+		return writer.WriteRow(balanceExportRow(bucket, location))
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+func (s *Server) ListPendingApprovals(ctx context.Context, req *pb.EmptyRequest) (*pb.ApprovalsResponse, error) {
+	if err := s.checkUserViewer(req.UserId); err != nil {
+		return nil, err
+	}
+
+	if err := s.ReconcileApprovals(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.storage.ExpireApprovals(ctx, time.Now()); err != nil {
+		return nil, err
+	}
+	approvals, err := s.storage.GetPendingApprovals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*pb.Approval, 0, len(approvals))
+	for _, approval := range approvals {
+		result = append(result, approvalToPb(approval))
+	}
+	return &pb.ApprovalsResponse{Approvals: result}, nil
+}
+
+// Approve runs the call of a pending approval, the approver must be another
+// operator than the one who made the call. A failing call still completes the
+// approval, the error is in its events.
+func (s *Server) Approve(ctx context.Context, req *pb.ApprovalRequest) (*pb.ApprovalResponse, error) {
+	if err := s.checkUserOperator(req.UserId); err != nil {
+		return nil, err
+	}
+
+	approval, err := s.pendingApproval(ctx, req.ApprovalId)
+	if err != nil {
+		return nil, err
+	}
+	if approval.RequestedBy == req.UserId {
+		return nil, status.Error(codes.PermissionDenied, "the approval must come from another operator")
+	}
+	request, err := approvalRequest(approval)
+	if err != nil {
+		return nil, err
+	}
+
+	// the approval stays executing if the service stops during the call,
+	// ReconcileApprovals fails it later
+	event := ApprovalEvent{At: time.Now(), UserId: req.UserId, Action: approvalApproved}
+	if err := s.decideApproval(ctx, approval, approvalPending, approvalExecuting, event); err != nil {
+		return nil, err
+	}
+
+	// the call skips the interceptors, so that it doesn't need another approval
+	event = ApprovalEvent{UserId: req.UserId, Action: approvalExecuted}
+	if _, err := invokeServer(ctx, s, nil, approval.Method, request); err != nil {
+		event.Action, event.Message = approvalFailed, status.Convert(err).Message()
+	}
+	event.At = time.Now()
+	if err := s.decideApproval(ctx, approval, approvalExecuting, event.Action, event); err != nil {
+		return nil, err
+	}
+
+	return s.approvalResponse(ctx, approval.Id)
+}
+
+// Reject drops a pending approval, any operator may reject it including the
+// one who made the call.
+func (s *Server) Reject(ctx context.Context, req *pb.ApprovalRequest) (*pb.ApprovalResponse, error) {
+	if err := s.checkUserOperator(req.UserId); err != nil {
+		return nil, err
+	}
+
+	approval, err := s.pendingApproval(ctx, req.ApprovalId)
+	if err != nil {
+		return nil, err
+	}
+
+	event := ApprovalEvent{At: time.Now(), UserId: req.UserId, Action: approvalRejected, Message: req.Reason}
+	if err := s.decideApproval(ctx, approval, approvalPending, approvalRejected, event); err != nil {
+		return nil, err
+	}
+
+	return s.approvalResponse(ctx, approval.Id)
+}
+
 func (s *Server) checkUserOperator(userId int64) error {
 	if !int64InList(userId, s.userOperators) {
 		return errUserNotOperator
@@ -288,6 +966,49 @@ func (s *Server) checkUserOperator(userId int64) error {
 	return nil
 }
 
+// pendingApproval returns the approval if it is still pending.
+func (s *Server) pendingApproval(ctx context.Context, approvalId string) (*Approval, error) {
+	if err := s.storage.ExpireApprovals(ctx, time.Now()); err != nil {
+		return nil, err
+	}
+	approval, err := s.storage.GetApproval(ctx, approvalId)
+	if err != nil {
+		return nil, err
+	}
+	if approval == nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("unknown approval '%s'", approvalId))
+	}
+	if approval.Status != approvalPending {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("approval %s is %s", approvalId, approval.Status))
+	}
+	return approval, nil
+}
+
+// decideApproval moves the approval to the next status and records the step.
+func (s *Server) decideApproval(ctx context.Context, approval *Approval, from, to string, event ApprovalEvent) error {
+	updated, err := s.storage.UpdateApprovalStatus(ctx, approval.Id, from, to, event)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return status.Error(codes.Aborted, fmt.Sprintf("approval %s was decided meanwhile", approval.Id))
+	}
+
+	s.recordApproval(ctx, approval, event)
+	return nil
+}
+
+func (s *Server) approvalResponse(ctx context.Context, approvalId string) (*pb.ApprovalResponse, error) {
+	approval, err := s.storage.GetApproval(ctx, approvalId)
+	if err != nil {
+		return nil, err
+	}
+	if approval == nil {
+		return nil, status.Error(codes.NotFound, fmt.Sprintf("unknown approval '%s'", approvalId))
+	}
+	return &pb.ApprovalResponse{Approval: approvalToPb(approval)}, nil
+}
+
 func (s *Server) checkUserViewer(userId int64) error {
 	if !int64InList(userId, s.userOperators) {
 		return errUserNotViewer
@@ -309,14 +1030,244 @@ func (s *Server) setSymbolStatus(
 		return nil, errSymbolNotFound(symbol)
 	}
 
+	if status == pb.TradingSymbol_ACTIVE {
+		halt, err := s.storage.GetHalt(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if halt != nil {
+			return nil, errTradingHalted
+		}
+	}
+
+	fields := []string{"status", "suspend_reason"}
+	if status == pb.TradingSymbol_ACTIVE {
+		// the circuit breaker counts only the losses after the symbol is resumed
+		tradingSymbol.ResumedAt = time.Now()
+		fields = append(fields, "resumed_at")
+	}
 	tradingSymbol.Status = status
-	if err := s.storage.SaveTradingSymbol(ctx, tradingSymbol); err != nil {
+	tradingSymbol.SuspendReason = ""
+	if err := s.storage.UpdateTradingSymbol(ctx, tradingSymbol, fields...); err != nil {
 		return nil, err
 	}
+	s.publishStatusChanged(symbol, status)
 
 	return &pb.EmptyResponse{}, nil
 }
 
+// halt stores the halt with the current statuses of the symbols, then suspends
+// them all. The user is 0 when the halt is triggered by the circuit breaker.
+func (s *Server) halt(ctx context.Context, userId int64, reason string, closeDeals bool) error {
+	tradingSymbols, err := s.storage.GetTradingSymbols(ctx)
+	if err != nil {
+		return err
+	}
+
+	halt := &Halt{
+		UserId:        userId,
+		Reason:        reason,
+		CreatedAt:     time.Now(),
+		PriorStatuses: make(map[string]pb.TradingSymbol_TradingStatus),
+	}
+	for _, symbol := range tradingSymbols {
+		halt.PriorStatuses[symbol.Symbol] = symbol.Status
+	}
+
+	created, err := s.storage.CreateHalt(ctx, halt)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return status.Error(codes.FailedPrecondition, "trading is already halted")
+	}
+	if err := s.storage.SuspendTradingSymbols(ctx, fmt.Sprintf("emergency halt: %s", reason)); err != nil {
+		return err
+	}
+
+	loggerFromContext(ctx, s.logger).Warnf("trading halted by %d: %s", userId, reason)
+	s.notifier.Publish(Event{
+		Type:    EventStatusChanged,
+		Message: fmt.Sprintf("trading is halted: %s", reason),
+		Data:    map[string]string{"status": "HALTED"},
+	})
+
+	if closeDeals {
+		return s.storage.EachDeal(ctx, nil, time.Time{}, time.Time{}, func(deal *Deal) error {
+			return s.closeDeal(ctx, deal)
+		})
+	}
+
+	return nil
+}
+
+// suspendSymbol suspends an active symbol on behalf of the service, the reason
+// is shown to operators until the symbol is resumed.
+func (s *Server) suspendSymbol(ctx context.Context, tradingSymbol *TradingSymbol, reason string) error {
+	tradingSymbol.Status = pb.TradingSymbol_SUSPENDED
+	tradingSymbol.SuspendReason = reason
+	if err := s.storage.UpdateTradingSymbol(ctx, tradingSymbol, "status", "suspend_reason"); err != nil {
+		return err
+	}
+
+	loggerFromContext(ctx, s.logger).Warnf("%s suspended: %s", tradingSymbol.Symbol, reason)
+	s.notifier.Publish(Event{
+		Type:    EventStatusChanged,
+		Symbol:  tradingSymbol.Symbol,
+		Message: fmt.Sprintf("%s is suspended: %s", tradingSymbol.Symbol, reason),
+		Data:    map[string]string{"status": tradingSymbol.Status.String(), "reason": reason},
+	})
+	return nil
+}
+
+func (s *Server) closeDeal(ctx context.Context, deal *Deal) error {
+	// the excursion tracker changes open deals, the deal is read again so that
+	// the closed deal keeps the last stored excursion
+	stored, err := s.storage.GetDeal(ctx, deal.Id)
+	if err != nil {
+		return err
+	}
+	if stored == nil {
+		return errDealNotFound(deal.Id)
+	}
+	*deal = *stored
+
+	if deal.Paper {
+		err = s.paper.CloseDeal(ctx, deal)
+	} else {
+		err = s.storage.DeleteDeal(ctx, deal)
+	}
+	if err != nil {
+		return err
+	}
+
+	// the deal is closed already, errors below only weaken the record of its result
+	deal.Excursion.update(deal.DeltaPercent, deal.Prediction)
+	if deal.Timeframe == "" {
+		if tradingSymbol, err := s.storage.GetTradingSymbol(ctx, deal.Symbol); err != nil {
+			loggerFromContext(ctx, s.logger).Errorf("cannot get timeframe of deal %s: %v", deal.Id, err)
+		} else if tradingSymbol != nil {
+			deal.Timeframe = tradingSymbol.Config.Timeframe
+		}
+	}
+	if err := s.storage.SaveClosedDeal(ctx, &ClosedDeal{*deal, time.Now()}); err != nil {
+		loggerFromContext(ctx, s.logger).Errorf("cannot save closed deal %s: %v", deal.Id, err)
+	}
+
+	stopHit := deal.DeltaPercent.LessThanOrEqual(decimal.NewFromFloat32(deal.Prediction.Stop))
+	s.notifier.Publish(Event{
+		Type:    EventDealClosed,
+		Symbol:  deal.Symbol,
+		Message: fmt.Sprintf("deal %s closed on %s with %s%%", deal.Id, deal.Symbol, deal.DeltaPercent.StringFixed(2)),
+		Data: map[string]string{
+			"deal_id":       deal.Id,
+			"delta_amount":  deal.DeltaAmount.String(),
+			"delta_percent": deal.DeltaPercent.String(),
+			"stop_hit":      fmt.Sprint(stopHit),
+		},
+	})
+
+	return nil
+}
+
+func (s *Server) publishStatusChanged(symbol string, status pb.TradingSymbol_TradingStatus) {
+	s.notifier.Publish(Event{
+		Type:    EventStatusChanged,
+		Symbol:  symbol,
+		Message: fmt.Sprintf("%s is %s", symbol, strings.ToLower(status.String())),
+		Data:    map[string]string{"status": status.String()},
+	})
+}
+
+// checkSymbolLimit publishes a limit breach when the open deals of the symbol
+// exceed its limit. It only notifies, errors are logged and never fail the call.
+func (s *Server) checkSymbolLimit(ctx context.Context, tradingSymbol *TradingSymbol) {
+	sums, err := s.storage.SumDeals(ctx, map[string]time.Time{tradingSymbol.Symbol: {}})
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Errorf("cannot check limit of %s: %v", tradingSymbol.Symbol, err)
+		return
+	}
+
+	exposure := decimal.Zero
+	if sum, ok := sums[tradingSymbol.Symbol]; ok {
+		exposure = sum.AmountCurrency
+	}
+
+	if exposure.GreaterThan(tradingSymbol.Limit) {
+		s.notifier.Publish(Event{
+			Type:    EventLimitBreached,
+			Symbol:  tradingSymbol.Symbol,
+			Message: fmt.Sprintf("%s exposure %s exceeds the limit %s", tradingSymbol.Symbol, exposure, tradingSymbol.Limit),
+			Data: map[string]string{
+				"exposure": exposure.String(),
+				"limit":    tradingSymbol.Limit.String(),
+			},
+		})
+	}
+}
+
+func dealToPb(deal *Deal) *pb.Deal {
+	return &pb.Deal{
+		DealId:         deal.Id,
+		Symbol:         deal.Symbol,
+		CreatedAt:      timestamppb.New(deal.CreatedAt),
+		Amount:         decimalToFloat(deal.Amount),
+		AmountCurrency: decimalToFloat(deal.AmountCurrency),
+		DeltaAmount:    decimalToFloat(deal.DeltaAmount),
+		DeltaPercent:   decimalToFloat(deal.DeltaPercent),
+		Prediction: &pb.Deal_DealPrediction{
+			Stop: deal.Prediction.Stop,
+			Max:  deal.Prediction.Max,
+		},
+		Paper:                 deal.Paper,
+		OpenPrice:             decimalToFloat(deal.OpenPrice),
+		AmountDecimal:         deal.Amount.String(),
+		AmountCurrencyDecimal: deal.AmountCurrency.String(),
+		DeltaAmountDecimal:    deal.DeltaAmount.String(),
+		DeltaPercentDecimal:   deal.DeltaPercent.String(),
+		OpenPriceDecimal:      deal.OpenPrice.String(),
+		Timeframe:             deal.Timeframe,
+		MaxFavorablePercent:   deal.Excursion.Favorable.String(),
+		MaxAdversePercent:     deal.Excursion.Adverse.String(),
+		FirstHit:              deal.Excursion.FirstHit,
+		Version:               deal.Version,
+	}
+}
+
+func scheduleToPb(schedule *Schedule) *pb.Schedule {
+	result := &pb.Schedule{
+		ScheduleId: schedule.Id,
+		Symbol:     schedule.Symbol,
+		Status:     schedule.Status,
+		Cron:       schedule.Cron,
+		LastError:  schedule.LastError,
+		CreatedBy:  schedule.CreatedBy,
+	}
+	if schedule.NextRunAt != nil {
+		result.NextRunAt = timestamppb.New(*schedule.NextRunAt)
+	}
+	if schedule.LastRunAt != nil {
+		result.LastRunAt = timestamppb.New(*schedule.LastRunAt)
+	}
+	return result
+}
+
+func subscriptionToPb(subscription *Subscription) *pb.Subscription {
+	result := &pb.Subscription{
+		Symbols: subscription.Symbols,
+	}
+	for _, event := range subscription.Events {
+		result.Events = append(result.Events, string(event))
+	}
+	for _, channel := range subscription.Channels {
+		result.Channels = append(result.Channels, &pb.NotificationChannel{
+			Sink:   channel.Sink,
+			Target: channel.Target,
+		})
+	}
+	return result
+}
+
 func int64InList(n int64, list []int64) bool {
 	for _, i := range list {
 		if i == n {
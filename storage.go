@@ -2,36 +2,102 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	pb "github.com/mikevel2955/gandalf/pb"
+	"github.com/shopspring/decimal"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Storage struct {
-	client *mongo.Client
-	dbName string
+	client  *mongo.Client
+	dbName  string
+	logger  *zap.SugaredLogger
+	metrics *Metrics
 }
 
 type TradingSymbol struct {
-	Symbol  string                         `bson:"_id"`
-	Status  pb.TradingSymbol_TradingStatus `bson:"status"`
-	Balance float32                        `bson:"balance"`
-	Limit   float32                        `bson:"limit"`
+	Symbol        string                         `bson:"_id"`
+	Status        pb.TradingSymbol_TradingStatus `bson:"status"`
+	Balance       decimal.Decimal                `bson:"balance"`
+	Limit         decimal.Decimal                `bson:"limit"`
+	Paper         bool                           `bson:"paper"`
+	MaxDrawdown   decimal.Decimal                `bson:"max_drawdown"`
+	SuspendReason string                         `bson:"suspend_reason,omitempty"`
+	ResumedAt     time.Time                      `bson:"resumed_at,omitempty"`
+	Config        SymbolConfig                   `bson:"config"`
+	Version       int64                          `bson:"version"`
+}
+
+// SymbolConfig is the strategy of a symbol, every change gets a new version
+// and is kept in the history.
+type SymbolConfig struct {
+	Version     int32           `bson:"version"`
+	Timeframe   string          `bson:"timeframe"`
+	EntryDelta  decimal.Decimal `bson:"entry_delta_percent"`
+	StopPercent decimal.Decimal `bson:"stop_percent"`
+	MaxPercent  decimal.Decimal `bson:"max_percent"`
+	MaxDeals    int32           `bson:"max_concurrent_deals"`
+	OrderSize   decimal.Decimal `bson:"order_size"`
+	UpdatedBy   int64           `bson:"updated_by"`
+	UpdatedAt   time.Time       `bson:"updated_at"`
+}
+
+type symbolConfigVersion struct {
+	Id           string `bson:"_id"`
+	Symbol       string `bson:"symbol"`
+	SymbolConfig `bson:",inline"`
 }
 
 type Deal struct {
-	Id             string         `bson:"_id"`
-	Symbol         string         `bson:"symbol"`
-	CreatedAt      time.Time      `bson:"created_at"`
-	Amount         float32        `bson:"amount"`
-	AmountCurrency float32        `bson:"amount_currency"`
-	DeltaAmount    float32        `bson:"delta_amount"`
-	DeltaPercent   float32        `bson:"delta_percent"`
-	Prediction     DealPrediction `bson:"prediction"`
+	Id             string          `bson:"_id"`
+	Symbol         string          `bson:"symbol"`
+	CreatedAt      time.Time       `bson:"created_at"`
+	Amount         decimal.Decimal `bson:"amount"`
+	AmountCurrency decimal.Decimal `bson:"amount_currency"`
+	DeltaAmount    decimal.Decimal `bson:"delta_amount"`
+	DeltaPercent   decimal.Decimal `bson:"delta_percent"`
+	Prediction     DealPrediction  `bson:"prediction"`
+	Paper          bool            `bson:"paper"`
+	OpenPrice      decimal.Decimal `bson:"open_price"`
+	Timeframe      string          `bson:"timeframe,omitempty"`
+	Excursion      DealExcursion   `bson:"excursion"`
+	Version        int64           `bson:"version"`
+}
+
+// DealSums are the totals of the open deals of a symbol. The open amount,
+// cost and delta amount only cover the deals with an open price.
+type DealSums struct {
+	Symbol          string          `bson:"_id"`
+	Count           int64           `bson:"count"`
+	AmountCurrency  decimal.Decimal `bson:"amount_currency"`
+	DeltaAmount     decimal.Decimal `bson:"delta_amount"`
+	OpenAmount      decimal.Decimal `bson:"open_amount"`
+	OpenCost        decimal.Decimal `bson:"open_cost"`
+	OpenDeltaAmount decimal.Decimal `bson:"open_delta_amount"`
+}
+
+// DealQuery selects a page of deals sorted by SortField and _id, empty
+// filters match all deals. The page starts after AfterValue and AfterId, the
+// sort value and id of the last deal of the previous page, if AfterId is set.
+type DealQuery struct {
+	Symbols    []string
+	DealIds    []string
+	From       time.Time
+	To         time.Time
+	SortField  string
+	Descending bool
+	Limit      int64
+	AfterValue interface{}
+	AfterId    string
 }
 
 type DealPrediction struct {
@@ -40,34 +106,274 @@ type DealPrediction struct {
 }
 
 const (
-	symbolsCollection = "symbols"
-	dealsCollection   = "deals"
+	hitStop = "stop"
+	hitMax  = "max"
+)
+
+// DealExcursion is the range of the delta percent of a deal while it is open
+// and which of the predicted levels it reached first.
+type DealExcursion struct {
+	Favorable decimal.Decimal `bson:"max_favorable_percent"`
+	Adverse   decimal.Decimal `bson:"max_adverse_percent"`
+	FirstHit  string          `bson:"first_hit,omitempty"`
+}
+
+// ClosedDeal keeps the result of a deal after it is closed.
+type ClosedDeal struct {
+	Deal     `bson:",inline"`
+	ClosedAt time.Time `bson:"closed_at"`
+}
+
+type Subscription struct {
+	UserId   int64                 `bson:"_id"`
+	Events   []EventType           `bson:"events"`
+	Symbols  []string              `bson:"symbols"`
+	Channels []SubscriptionChannel `bson:"channels"`
+}
+
+type SubscriptionChannel struct {
+	Sink   string `bson:"sink"`
+	Target string `bson:"target"`
+}
+
+// Halt is stored while trading is halted by EmergencyHalt, it keeps the
+// statuses of the symbols to restore on EmergencyResume.
+type Halt struct {
+	Id            string                                    `bson:"_id"`
+	UserId        int64                                     `bson:"user_id"`
+	Reason        string                                    `bson:"reason"`
+	CreatedAt     time.Time                                 `bson:"created_at"`
+	PriorStatuses map[string]pb.TradingSymbol_TradingStatus `bson:"prior_statuses"`
+}
+
+// HaltResume is kept after EmergencyResume, the circuit breaker counts only
+// the losses after it.
+type HaltResume struct {
+	Id        string    `bson:"_id"`
+	UserId    int64     `bson:"user_id"`
+	ResumedAt time.Time `bson:"resumed_at"`
+}
+
+// Schedule changes the status of a symbol once at NextRunAt, or repeatedly
+// when Cron is set. NextRunAt is nil when a single change is done.
+type Schedule struct {
+	Id        string                         `bson:"_id"`
+	Symbol    string                         `bson:"symbol"`
+	Status    pb.TradingSymbol_TradingStatus `bson:"status"`
+	Cron      string                         `bson:"cron,omitempty"`
+	NextRunAt *time.Time                     `bson:"next_run_at"`
+	LastRunAt *time.Time                     `bson:"last_run_at,omitempty"`
+	LastError string                         `bson:"last_error,omitempty"`
+	CreatedBy int64                          `bson:"created_by"`
+	CreatedAt time.Time                      `bson:"created_at"`
+}
+
+// IdempotencyRecord keeps the response to an idempotency key, Mongo removes
+// it at ExpiresAt. The response is empty while the request is in progress.
+type IdempotencyRecord struct {
+	Id           string    `bson:"_id"`
+	RequestHash  string    `bson:"request_hash"`
+	ResponseType string    `bson:"response_type,omitempty"`
+	Response     []byte    `bson:"response,omitempty"`
+	CreatedAt    time.Time `bson:"created_at"`
+	LockedUntil  time.Time `bson:"locked_until"`
+	ExpiresAt    time.Time `bson:"expires_at"`
+}
+
+// balanceBucketSize is the time a BalanceBucket covers. Every UTC offset in use
+// is a multiple of a quarter of an hour, so days start on a bucket in every
+// timezone.
+const balanceBucketSize = 15 * time.Minute
+
+// BalanceBucket aggregates the balance snapshots of a symbol taken within
+// balanceBucketSize, so that the history has a document per symbol and bucket
+// however often snapshots are taken. Balance and Value are of the last
+// snapshot, the value is in the quote currency.
+type BalanceBucket struct {
+	Id       string          `bson:"_id"`
+	Symbol   string          `bson:"symbol"`
+	Quote    string          `bson:"quote"`
+	Paper    bool            `bson:"paper"`
+	Start    time.Time       `bson:"start"`
+	Count    int64           `bson:"count"`
+	Balance  decimal.Decimal `bson:"balance"`
+	Value    decimal.Decimal `bson:"value"`
+	MinValue decimal.Decimal `bson:"min_value"`
+	MaxValue decimal.Decimal `bson:"max_value"`
+	LastAt   time.Time       `bson:"last_at"`
+}
+
+// Approval is a call of an rpc waiting for a second operator, the request is
+// kept as protobuf to be replayed once approved. Events record every step.
+type Approval struct {
+	Id          string          `bson:"_id"`
+	Method      string          `bson:"method"`
+	RequestType string          `bson:"request_type"`
+	Request     []byte          `bson:"request"`
+	RequestedBy int64           `bson:"requested_by"`
+	Status      string          `bson:"status"`
+	CreatedAt   time.Time       `bson:"created_at"`
+	UpdatedAt   time.Time       `bson:"updated_at"`
+	ExpiresAt   time.Time       `bson:"expires_at"`
+	Events      []ApprovalEvent `bson:"events"`
+}
+
+type ApprovalEvent struct {
+	At      time.Time `bson:"at"`
+	UserId  int64     `bson:"user_id"`
+	Action  string    `bson:"action"`
+	Message string    `bson:"message,omitempty"`
+}
+
+// Statuses of approvals, the final statuses are also the actions of the events
+// that lead to them. An approval is executing while its call runs, the event
+// that starts it is the approval.
+const (
+	approvalPending   = "pending"
+	approvalExecuting = "executing"
+	approvalRejected  = "rejected"
+	approvalExpired   = "expired"
+	approvalExecuted  = "executed"
+	approvalFailed    = "failed"
+
+	approvalRequested = "requested"
+	approvalApproved  = "approved"
+)
+
+const (
+	symbolsCollection       = "symbols"
+	dealsCollection         = "deals"
+	subscriptionsCollection = "subscriptions"
+	haltsCollection         = "halts"
+	closedDealsCollection   = "closed_deals"
+	schedulesCollection     = "schedules"
+	symbolConfigsCollection = "symbol_configs"
+	idempotencyCollection   = "idempotency_keys"
+	balancesCollection      = "balance_history"
+	approvalsCollection     = "approvals"
+
+	haltId       = "halt"
+	haltResumeId = "resume"
 )
 
 func NewStorage(
 	client *mongo.Client,
 	dbName string,
+	logger *zap.SugaredLogger,
+	metrics *Metrics,
 ) *Storage {
 	return &Storage{
-		client: client,
-		dbName: dbName,
+		client:  client,
+		dbName:  dbName,
+		logger:  logger,
+		metrics: metrics,
 	}
 }
 
-func (s *Storage) SaveTradingSymbol(ctx context.Context, tradingSymbol *TradingSymbol) error {
-	_, err := s.getSymbolsCollection().ReplaceOne(
+// errVersionConflict is returned when a document was changed or removed since
+// it was read, the caller is expected to read it again and retry.
+var errVersionConflict = func(kind, id string) error {
+	return status.Error(codes.Aborted, fmt.Sprintf("%s '%s' was changed concurrently, retry", kind, id))
+}
+
+// CreateTradingSymbol inserts the symbol at version 1, it returns false if
+// the symbol exists already.
+func (s *Storage) CreateTradingSymbol(ctx context.Context, tradingSymbol *TradingSymbol) (bool, error) {
+	defer s.metrics.observeStorage("CreateTradingSymbol", time.Now())
+
+	tradingSymbol.Version = 1
+	_, err := s.getSymbolsCollection().InsertOne(ctx, tradingSymbol)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// UpdateTradingSymbol sets the given fields of the symbol if it is still at
+// the version it was read at, and increments the version.
+func (s *Storage) UpdateTradingSymbol(ctx context.Context, tradingSymbol *TradingSymbol, fields ...string) error {
+	defer s.metrics.observeStorage("UpdateTradingSymbol", time.Now())
+
+	values := map[string]interface{}{
+		"status":         tradingSymbol.Status,
+		"balance":        tradingSymbol.Balance,
+		"limit":          tradingSymbol.Limit,
+		"paper":          tradingSymbol.Paper,
+		"max_drawdown":   tradingSymbol.MaxDrawdown,
+		"suspend_reason": tradingSymbol.SuspendReason,
+		"resumed_at":     tradingSymbol.ResumedAt,
+		"config":         tradingSymbol.Config,
+	}
+	set := bson.M{}
+	for _, field := range fields {
+		value, ok := values[field]
+		if !ok {
+			return errors.New(fmt.Sprintf("unknown symbol field '%s'", field))
+		}
+		set[field] = value
+	}
+
+	result, err := s.getSymbolsCollection().UpdateOne(
 		ctx,
-		bson.M{"_id": tradingSymbol.Symbol},
-		tradingSymbol,
-		options.Replace().SetUpsert(true),
+		bson.M{"_id": tradingSymbol.Symbol, "version": tradingSymbol.Version},
+		bson.M{"$set": set, "$inc": bson.M{"version": 1}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errVersionConflict("symbol", tradingSymbol.Symbol)
+	}
+
+	tradingSymbol.Version++
+	return nil
+}
+
+// AddTradingSymbolBalance changes the balance by the amount, as an increment
+// it never conflicts with other changes.
+func (s *Storage) AddTradingSymbolBalance(ctx context.Context, symbol string, amount decimal.Decimal) error {
+	defer s.metrics.observeStorage("AddTradingSymbolBalance", time.Now())
+
+	_, err := s.getSymbolsCollection().UpdateOne(
+		ctx,
+		bson.M{"_id": symbol},
+		bson.M{"$inc": bson.M{"balance": amount, "version": 1}},
 	)
 	return err
 }
 
 func (s *Storage) GetTradingSymbols(ctx context.Context) ([]*TradingSymbol, error) {
+	defer s.metrics.observeStorage("GetTradingSymbols", time.Now())
+
 	cursor, err := s.getSymbolsCollection().Find(ctx, bson.M{})
 	if err != nil {
-		fmt.Println(err)
+		loggerFromContext(ctx, s.logger).Errorf("cannot find symbols: %v", err)
+		return nil, err
+	}
+
+	symbols := make([]*TradingSymbol, 0)
+	if err := cursor.All(ctx, &symbols); err != nil {
+		return nil, err
+	}
+
+	return symbols, nil
+}
+
+// FindTradingSymbols returns at most limit symbols ordered by symbol, starting
+// after the symbol after.
+func (s *Storage) FindTradingSymbols(ctx context.Context, after string, limit int64) ([]*TradingSymbol, error) {
+	defer s.metrics.observeStorage("FindTradingSymbols", time.Now())
+
+	filter := bson.M{}
+	if after != "" {
+		filter["_id"] = bson.M{"$gt": after}
+	}
+	cursor, err := s.getSymbolsCollection().Find(ctx, filter, options.Find().SetSort(bson.M{"_id": 1}).SetLimit(limit))
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Errorf("cannot find symbols: %v", err)
 		return nil, err
 	}
 
@@ -80,6 +386,8 @@ func (s *Storage) GetTradingSymbols(ctx context.Context) ([]*TradingSymbol, erro
 }
 
 func (s *Storage) GetTradingSymbol(ctx context.Context, symbol string) (*TradingSymbol, error) {
+	defer s.metrics.observeStorage("GetTradingSymbol", time.Now())
+
 	document := s.getSymbolsCollection().FindOne(ctx, bson.M{"_id": symbol})
 	if document.Err() == mongo.ErrNoDocuments {
 		return nil, nil
@@ -96,24 +404,125 @@ func (s *Storage) GetTradingSymbol(ctx context.Context, symbol string) (*Trading
 }
 
 func (s *Storage) DeleteTradingSymbol(ctx context.Context, symbol string) error {
+	defer s.metrics.observeStorage("DeleteTradingSymbol", time.Now())
+
 	_, err := s.getSymbolsCollection().DeleteOne(ctx, bson.M{"_id": symbol})
 	return err
 }
 
-func (s *Storage) SaveDeal(ctx context.Context, deal *Deal) error {
-	_, err := s.getDealsCollection().ReplaceOne(
-		ctx,
-		bson.M{"_id": deal.Id},
-		deal,
-		options.Replace().SetUpsert(true),
-	)
+// CreateDeal inserts the deal at version 1.
+func (s *Storage) CreateDeal(ctx context.Context, deal *Deal) error {
+	defer s.metrics.observeStorage("CreateDeal", time.Now())
+
+	deal.Version = 1
+	_, err := s.getDealsCollection().InsertOne(ctx, deal)
 	return err
 }
 
-func (s *Storage) GetDeals(ctx context.Context) ([]*Deal, error) {
-	cursor, err := s.getDealsCollection().Find(ctx, bson.M{})
+// CountDeals returns the number of open deals of the symbol.
+func (s *Storage) CountDeals(ctx context.Context, symbol string) (int64, error) {
+	defer s.metrics.observeStorage("CountDeals", time.Now())
+
+	return s.getDealsCollection().CountDocuments(ctx, bson.M{"symbol": symbol})
+}
+
+// SumDeals sums the open deals of the symbols in starts, a symbol with a start
+// only counts the deals created after it. Symbols without deals are missing
+// from the result.
+func (s *Storage) SumDeals(ctx context.Context, starts map[string]time.Time) (map[string]*DealSums, error) {
+	defer s.metrics.observeStorage("SumDeals", time.Now())
+
+	result := make(map[string]*DealSums)
+	if len(starts) == 0 {
+		return result, nil
+	}
+
+	// every condition selects a range of the symbol_created_at index
+	conditions := bson.A{}
+	for symbol, start := range starts {
+		condition := bson.M{"symbol": symbol}
+		if !start.IsZero() {
+			condition["created_at"] = bson.M{"$gt": start}
+		}
+		conditions = append(conditions, condition)
+	}
+	opened := bson.M{"$gt": bson.A{"$open_price", 0}}
+	openSum := func(value interface{}) bson.M {
+		return bson.M{"$sum": bson.M{"$cond": bson.A{opened, value, 0}}}
+	}
+
+	cursor, err := s.getDealsCollection().Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"$or": conditions}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":               "$symbol",
+			"count":             bson.M{"$sum": 1},
+			"amount_currency":   bson.M{"$sum": "$amount_currency"},
+			"delta_amount":      bson.M{"$sum": "$delta_amount"},
+			"open_amount":       openSum("$amount"),
+			"open_cost":         openSum(bson.M{"$multiply": bson.A{"$open_price", "$amount"}}),
+			"open_delta_amount": openSum("$delta_amount"),
+		}}},
+	})
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Errorf("cannot sum deals: %v", err)
+		return nil, err
+	}
+
+	sums := make([]*DealSums, 0)
+	if err := cursor.All(ctx, &sums); err != nil {
+		return nil, err
+	}
+	for _, sum := range sums {
+		result[sum.Symbol] = sum
+	}
+
+	return result, nil
+}
+
+// FindDeals returns a page of the deals selected by the query.
+func (s *Storage) FindDeals(ctx context.Context, query *DealQuery) ([]*Deal, error) {
+	defer s.metrics.observeStorage("FindDeals", time.Now())
+
+	conditions := bson.A{}
+	if len(query.Symbols) > 0 {
+		conditions = append(conditions, bson.M{"symbol": bson.M{"$in": query.Symbols}})
+	}
+	if len(query.DealIds) > 0 {
+		conditions = append(conditions, bson.M{"_id": bson.M{"$in": query.DealIds}})
+	}
+	createdAt := bson.M{}
+	if !query.From.IsZero() {
+		createdAt["$gte"] = query.From
+	}
+	if !query.To.IsZero() {
+		createdAt["$lt"] = query.To
+	}
+	if len(createdAt) > 0 {
+		conditions = append(conditions, bson.M{"created_at": createdAt})
+	}
+
+	order, compare := 1, "$gt"
+	if query.Descending {
+		order, compare = -1, "$lt"
+	}
+	if query.AfterId != "" {
+		conditions = append(conditions, bson.M{"$or": bson.A{
+			bson.M{query.SortField: bson.M{compare: query.AfterValue}},
+			bson.M{query.SortField: query.AfterValue, "_id": bson.M{compare: query.AfterId}},
+		}})
+	}
+
+	filter := bson.M{}
+	if len(conditions) > 0 {
+		filter["$and"] = conditions
+	}
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: query.SortField, Value: order}, {Key: "_id", Value: order}}).
+		SetLimit(query.Limit)
+
+	cursor, err := s.getDealsCollection().Find(ctx, filter, findOptions)
 	if err != nil {
-		fmt.Println(err)
+		loggerFromContext(ctx, s.logger).Errorf("cannot find deals: %v", err)
 		return nil, err
 	}
 
@@ -125,7 +534,69 @@ func (s *Storage) GetDeals(ctx context.Context) ([]*Deal, error) {
 	return deals, nil
 }
 
+// EachDeal calls fn for the open deals of the symbols, or of all symbols,
+// created within the range in the order they were created. A zero bound
+// leaves its side of the range open.
+func (s *Storage) EachDeal(ctx context.Context, symbols []string, from, to time.Time, fn func(*Deal) error) error {
+	defer s.metrics.observeStorage("EachDeal", time.Now())
+
+	filter := bson.M{}
+	createdAt := bson.M{}
+	if !from.IsZero() {
+		createdAt["$gte"] = from
+	}
+	if !to.IsZero() {
+		createdAt["$lt"] = to
+	}
+	if len(createdAt) > 0 {
+		filter["created_at"] = createdAt
+	}
+	if len(symbols) > 0 {
+		filter["symbol"] = bson.M{"$in": symbols}
+	}
+	cursor, err := s.getDealsCollection().Find(ctx, filter, options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Errorf("cannot find deals: %v", err)
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		deal := &Deal{}
+		if err := cursor.Decode(deal); err != nil {
+			return err
+		}
+		if err := fn(deal); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// UpdateDealExcursion sets the excursion if the deal is still at the version it
+// was read at, and increments the version like any other change of the deal.
+func (s *Storage) UpdateDealExcursion(ctx context.Context, deal *Deal) error {
+	defer s.metrics.observeStorage("UpdateDealExcursion", time.Now())
+
+	result, err := s.getDealsCollection().UpdateOne(
+		ctx,
+		bson.M{"_id": deal.Id, "version": deal.Version},
+		bson.M{"$set": bson.M{"excursion": deal.Excursion}, "$inc": bson.M{"version": 1}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errVersionConflict("deal", deal.Id)
+	}
+
+	deal.Version++
+	return nil
+}
+
 func (s *Storage) GetDeal(ctx context.Context, dealId string) (*Deal, error) {
+	defer s.metrics.observeStorage("GetDeal", time.Now())
+
 	document := s.getDealsCollection().FindOne(ctx, bson.M{"_id": dealId})
 	if document.Err() == mongo.ErrNoDocuments {
 		return nil, nil
@@ -141,11 +612,588 @@ func (s *Storage) GetDeal(ctx context.Context, dealId string) (*Deal, error) {
 	return deal, nil
 }
 
-func (s *Storage) DeleteDeal(ctx context.Context, dealId string) error {
-	_, err := s.getDealsCollection().DeleteOne(ctx, bson.M{"_id": dealId})
+// DeleteDeal removes the deal if it is still at the version it was read at.
+func (s *Storage) DeleteDeal(ctx context.Context, deal *Deal) error {
+	defer s.metrics.observeStorage("DeleteDeal", time.Now())
+
+	result, err := s.getDealsCollection().DeleteOne(ctx, bson.M{"_id": deal.Id, "version": deal.Version})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return errVersionConflict("deal", deal.Id)
+	}
+
+	return nil
+}
+
+func (s *Storage) SaveClosedDeal(ctx context.Context, deal *ClosedDeal) error {
+	defer s.metrics.observeStorage("SaveClosedDeal", time.Now())
+
+	_, err := s.getClosedDealsCollection().ReplaceOne(
+		ctx,
+		bson.M{"_id": deal.Id},
+		deal,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+// EachClosedDeal calls fn for the deals of the symbols, or of all symbols,
+// closed within the range in the order they were closed. A zero bound leaves
+// its side of the range open. The deals are read one by one, so that a long
+// history isn't loaded at once.
+func (s *Storage) EachClosedDeal(ctx context.Context, symbols []string, from, to time.Time, fn func(*ClosedDeal) error) error {
+	defer s.metrics.observeStorage("EachClosedDeal", time.Now())
+
+	filter := bson.M{}
+	closedAt := bson.M{}
+	if !from.IsZero() {
+		closedAt["$gte"] = from
+	}
+	if !to.IsZero() {
+		closedAt["$lt"] = to
+	}
+	if len(closedAt) > 0 {
+		filter["closed_at"] = closedAt
+	}
+	if len(symbols) > 0 {
+		filter["symbol"] = bson.M{"$in": symbols}
+	}
+	cursor, err := s.getClosedDealsCollection().Find(ctx, filter, options.Find().SetSort(bson.M{"closed_at": 1}))
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Errorf("cannot find closed deals: %v", err)
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		deal := &ClosedDeal{}
+		if err := cursor.Decode(deal); err != nil {
+			return err
+		}
+		if err := fn(deal); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// AddBalanceSnapshot adds a snapshot of the balance of a symbol to the bucket
+// it was taken in.
+func (s *Storage) AddBalanceSnapshot(
+	ctx context.Context,
+	symbol *TradingSymbol,
+	quote string,
+	value decimal.Decimal,
+	at time.Time,
+) error {
+	defer s.metrics.observeStorage("AddBalanceSnapshot", time.Now())
+
+	start := at.UTC().Truncate(balanceBucketSize)
+	_, err := s.getBalancesCollection().UpdateOne(
+		ctx,
+		bson.M{"_id": fmt.Sprintf("%s:%s:%d", symbol.Symbol, quote, start.Unix())},
+		bson.M{
+			"$setOnInsert": bson.M{"symbol": symbol.Symbol, "quote": quote, "start": start},
+			"$set":         bson.M{"paper": symbol.Paper, "balance": symbol.Balance, "value": value, "last_at": at},
+			"$min":         bson.M{"min_value": value},
+			"$max":         bson.M{"max_value": value},
+			"$inc":         bson.M{"count": 1},
+		},
+		options.Update().SetUpsert(true),
+	)
+	return err
+}
+
+// EachBalanceBucket calls fn for the buckets of the symbols, or of all
+// symbols, in the quote currency, or in all currencies if it is empty, that
+// start within the range, ordered by start.
+func (s *Storage) EachBalanceBucket(
+	ctx context.Context,
+	symbols []string,
+	quote string,
+	from, to time.Time,
+	fn func(*BalanceBucket) error,
+) error {
+	defer s.metrics.observeStorage("EachBalanceBucket", time.Now())
+
+	filter := bson.M{"start": bson.M{"$gte": from, "$lt": to}}
+	if quote != "" {
+		filter["quote"] = quote
+	}
+	if len(symbols) > 0 {
+		filter["symbol"] = bson.M{"$in": symbols}
+	}
+	cursor, err := s.getBalancesCollection().Find(ctx, filter, options.Find().SetSort(bson.M{"start": 1}))
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Errorf("cannot find balance history: %v", err)
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		bucket := &BalanceBucket{}
+		if err := cursor.Decode(bucket); err != nil {
+			return err
+		}
+		if err := fn(bucket); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (s *Storage) CreateApproval(ctx context.Context, approval *Approval) error {
+	defer s.metrics.observeStorage("CreateApproval", time.Now())
+
+	_, err := s.getApprovalsCollection().InsertOne(ctx, approval)
+	return err
+}
+
+func (s *Storage) GetApproval(ctx context.Context, id string) (*Approval, error) {
+	defer s.metrics.observeStorage("GetApproval", time.Now())
+
+	document := s.getApprovalsCollection().FindOne(ctx, bson.M{"_id": id})
+	if document.Err() == mongo.ErrNoDocuments {
+		return nil, nil
+	} else if document.Err() != nil {
+		return nil, document.Err()
+	}
+
+	approval := &Approval{}
+	if err := document.Decode(approval); err != nil {
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+func (s *Storage) GetPendingApprovals(ctx context.Context) ([]*Approval, error) {
+	defer s.metrics.observeStorage("GetPendingApprovals", time.Now())
+
+	cursor, err := s.getApprovalsCollection().Find(
+		ctx,
+		bson.M{"status": approvalPending},
+		options.Find().SetSort(bson.M{"created_at": 1}),
+	)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Errorf("cannot find approvals: %v", err)
+		return nil, err
+	}
+
+	approvals := make([]*Approval, 0)
+	if err := cursor.All(ctx, &approvals); err != nil {
+		return nil, err
+	}
+
+	return approvals, nil
+}
+
+// GetStaleApprovals returns the approvals in the status since before the time.
+func (s *Storage) GetStaleApprovals(ctx context.Context, status string, before time.Time) ([]*Approval, error) {
+	defer s.metrics.observeStorage("GetStaleApprovals", time.Now())
+
+	cursor, err := s.getApprovalsCollection().Find(ctx, bson.M{"status": status, "updated_at": bson.M{"$lte": before}})
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Errorf("cannot find approvals: %v", err)
+		return nil, err
+	}
+
+	approvals := make([]*Approval, 0)
+	if err := cursor.All(ctx, &approvals); err != nil {
+		return nil, err
+	}
+
+	return approvals, nil
+}
+
+// UpdateApprovalStatus moves the approval from one status to another and
+// records the event, it returns false if the approval is not in the status
+// anymore, so that only one operator can decide on it.
+func (s *Storage) UpdateApprovalStatus(ctx context.Context, id string, from, to string, event ApprovalEvent) (bool, error) {
+	defer s.metrics.observeStorage("UpdateApprovalStatus", time.Now())
+
+	result, err := s.getApprovalsCollection().UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": from},
+		bson.M{"$set": bson.M{"status": to, "updated_at": event.At}, "$push": bson.M{"events": event}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.MatchedCount == 1, nil
+}
+
+// ExpireApprovals marks the pending approvals expired at now as expired.
+func (s *Storage) ExpireApprovals(ctx context.Context, now time.Time) error {
+	defer s.metrics.observeStorage("ExpireApprovals", time.Now())
+
+	_, err := s.getApprovalsCollection().UpdateMany(
+		ctx,
+		bson.M{"status": approvalPending, "expires_at": bson.M{"$lte": now}},
+		bson.M{
+			"$set":  bson.M{"status": approvalExpired, "updated_at": now},
+			"$push": bson.M{"events": ApprovalEvent{At: now, Action: approvalExpired}},
+		},
+	)
+	return err
+}
+
+// AddSymbolConfig adds the config to the history of the symbol, it returns
+// false if the version is there already.
+func (s *Storage) AddSymbolConfig(ctx context.Context, symbol string, config SymbolConfig) (bool, error) {
+	defer s.metrics.observeStorage("AddSymbolConfig", time.Now())
+
+	_, err := s.getSymbolConfigsCollection().InsertOne(ctx, &symbolConfigVersion{
+		Id:           fmt.Sprintf("%s:%d", symbol, config.Version),
+		Symbol:       symbol,
+		SymbolConfig: config,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetSymbolConfigHistory returns all versions of the config, newest first.
+func (s *Storage) GetSymbolConfigHistory(ctx context.Context, symbol string) ([]*SymbolConfig, error) {
+	defer s.metrics.observeStorage("GetSymbolConfigHistory", time.Now())
+
+	cursor, err := s.getSymbolConfigsCollection().Find(
+		ctx,
+		bson.M{"symbol": symbol},
+		options.Find().SetSort(bson.M{"version": -1}),
+	)
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Errorf("cannot find configs of %s: %v", symbol, err)
+		return nil, err
+	}
+
+	versions := make([]*symbolConfigVersion, 0)
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, err
+	}
+
+	configs := make([]*SymbolConfig, 0, len(versions))
+	for _, version := range versions {
+		config := version.SymbolConfig
+		configs = append(configs, &config)
+	}
+
+	return configs, nil
+}
+
+func (s *Storage) SaveSchedule(ctx context.Context, schedule *Schedule) error {
+	defer s.metrics.observeStorage("SaveSchedule", time.Now())
+
+	_, err := s.getSchedulesCollection().ReplaceOne(
+		ctx,
+		bson.M{"_id": schedule.Id},
+		schedule,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (s *Storage) GetSchedules(ctx context.Context) ([]*Schedule, error) {
+	defer s.metrics.observeStorage("GetSchedules", time.Now())
+
+	cursor, err := s.getSchedulesCollection().Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Errorf("cannot find schedules: %v", err)
+		return nil, err
+	}
+
+	schedules := make([]*Schedule, 0)
+	if err := cursor.All(ctx, &schedules); err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// GetDueSchedules returns the schedules to run at the given time.
+func (s *Storage) GetDueSchedules(ctx context.Context, now time.Time) ([]*Schedule, error) {
+	defer s.metrics.observeStorage("GetDueSchedules", time.Now())
+
+	cursor, err := s.getSchedulesCollection().Find(ctx, bson.M{"next_run_at": bson.M{"$lte": now}})
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Errorf("cannot find due schedules: %v", err)
+		return nil, err
+	}
+
+	schedules := make([]*Schedule, 0)
+	if err := cursor.All(ctx, &schedules); err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// ClaimSchedule moves the next run of the schedule if it was not moved by
+// another instance yet, it returns false in that case.
+func (s *Storage) ClaimSchedule(ctx context.Context, schedule *Schedule, nextRunAt *time.Time) (bool, error) {
+	defer s.metrics.observeStorage("ClaimSchedule", time.Now())
+
+	result, err := s.getSchedulesCollection().UpdateOne(
+		ctx,
+		bson.M{"_id": schedule.Id, "next_run_at": schedule.NextRunAt},
+		bson.M{"$set": bson.M{"next_run_at": nextRunAt}},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
+
+// SetScheduleResult records the last run of the schedule.
+func (s *Storage) SetScheduleResult(ctx context.Context, scheduleId string, runAt time.Time, runErr string) error {
+	defer s.metrics.observeStorage("SetScheduleResult", time.Now())
+
+	_, err := s.getSchedulesCollection().UpdateOne(
+		ctx,
+		bson.M{"_id": scheduleId},
+		bson.M{"$set": bson.M{"last_run_at": runAt, "last_error": runErr}},
+	)
+	return err
+}
+
+// DeleteSchedule returns false if there is no such schedule.
+func (s *Storage) DeleteSchedule(ctx context.Context, scheduleId string) (bool, error) {
+	defer s.metrics.observeStorage("DeleteSchedule", time.Now())
+
+	result, err := s.getSchedulesCollection().DeleteOne(ctx, bson.M{"_id": scheduleId})
+	if err != nil {
+		return false, err
+	}
+
+	return result.DeletedCount == 1, nil
+}
+
+// ReserveIdempotencyKey inserts the record, it returns false if the key is
+// there already, unless the lock of an unfinished call of the same request has
+// expired.
+func (s *Storage) ReserveIdempotencyKey(ctx context.Context, record *IdempotencyRecord) (bool, error) {
+	defer s.metrics.observeStorage("ReserveIdempotencyKey", time.Now())
+
+	_, err := s.getIdempotencyCollection().InsertOne(ctx, record)
+	if err == nil {
+		return true, nil
+	} else if !mongo.IsDuplicateKeyError(err) {
+		return false, err
+	}
+
+	// the call holding an expired lock died without a response, the same
+	// request takes the key over
+	result, err := s.getIdempotencyCollection().UpdateOne(
+		ctx,
+		bson.M{
+			"_id":           record.Id,
+			"request_hash":  record.RequestHash,
+			"response_type": bson.M{"$exists": false},
+			"$or": bson.A{
+				bson.M{"locked_until": bson.M{"$lte": record.CreatedAt}},
+				bson.M{"locked_until": bson.M{"$exists": false}},
+			},
+		},
+		bson.M{"$set": bson.M{
+			"created_at":   record.CreatedAt,
+			"locked_until": record.LockedUntil,
+			"expires_at":   record.ExpiresAt,
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+func (s *Storage) GetIdempotencyKey(ctx context.Context, id string) (*IdempotencyRecord, error) {
+	defer s.metrics.observeStorage("GetIdempotencyKey", time.Now())
+
+	document := s.getIdempotencyCollection().FindOne(ctx, bson.M{"_id": id})
+	if document.Err() == mongo.ErrNoDocuments {
+		return nil, nil
+	} else if document.Err() != nil {
+		return nil, document.Err()
+	}
+
+	record := &IdempotencyRecord{}
+	if err := document.Decode(record); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (s *Storage) CompleteIdempotencyKey(ctx context.Context, id string, responseType string, response []byte) error {
+	defer s.metrics.observeStorage("CompleteIdempotencyKey", time.Now())
+
+	_, err := s.getIdempotencyCollection().UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"response_type": responseType, "response": response}},
+	)
+	return err
+}
+
+func (s *Storage) DeleteIdempotencyKey(ctx context.Context, id string) error {
+	defer s.metrics.observeStorage("DeleteIdempotencyKey", time.Now())
+
+	_, err := s.getIdempotencyCollection().DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+func (s *Storage) SaveSubscription(ctx context.Context, subscription *Subscription) error {
+	defer s.metrics.observeStorage("SaveSubscription", time.Now())
+
+	_, err := s.getSubscriptionsCollection().ReplaceOne(
+		ctx,
+		bson.M{"_id": subscription.UserId},
+		subscription,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (s *Storage) GetSubscription(ctx context.Context, userId int64) (*Subscription, error) {
+	defer s.metrics.observeStorage("GetSubscription", time.Now())
+
+	document := s.getSubscriptionsCollection().FindOne(ctx, bson.M{"_id": userId})
+	if document.Err() == mongo.ErrNoDocuments {
+		return nil, nil
+	} else if document.Err() != nil {
+		return nil, document.Err()
+	}
+
+	subscription := &Subscription{}
+	if err := document.Decode(subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (s *Storage) GetSubscriptions(ctx context.Context) ([]*Subscription, error) {
+	defer s.metrics.observeStorage("GetSubscriptions", time.Now())
+
+	cursor, err := s.getSubscriptionsCollection().Find(ctx, bson.M{})
+	if err != nil {
+		loggerFromContext(ctx, s.logger).Errorf("cannot find subscriptions: %v", err)
+		return nil, err
+	}
+
+	subscriptions := make([]*Subscription, 0)
+	if err := cursor.All(ctx, &subscriptions); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// CreateHalt stores the halt unless trading is halted already, it returns
+// false in that case.
+func (s *Storage) CreateHalt(ctx context.Context, halt *Halt) (bool, error) {
+	defer s.metrics.observeStorage("CreateHalt", time.Now())
+
+	halt.Id = haltId
+	_, err := s.getHaltsCollection().InsertOne(ctx, halt)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (s *Storage) GetHalt(ctx context.Context) (*Halt, error) {
+	defer s.metrics.observeStorage("GetHalt", time.Now())
+
+	document := s.getHaltsCollection().FindOne(ctx, bson.M{"_id": haltId})
+	if document.Err() == mongo.ErrNoDocuments {
+		return nil, nil
+	} else if document.Err() != nil {
+		return nil, document.Err()
+	}
+
+	halt := &Halt{}
+	if err := document.Decode(halt); err != nil {
+		return nil, err
+	}
+
+	return halt, nil
+}
+
+func (s *Storage) DeleteHalt(ctx context.Context) error {
+	defer s.metrics.observeStorage("DeleteHalt", time.Now())
+
+	_, err := s.getHaltsCollection().DeleteOne(ctx, bson.M{"_id": haltId})
+	return err
+}
+
+// SaveHaltResume replaces the last resume of trading.
+func (s *Storage) SaveHaltResume(ctx context.Context, resume *HaltResume) error {
+	defer s.metrics.observeStorage("SaveHaltResume", time.Now())
+
+	resume.Id = haltResumeId
+	_, err := s.getHaltsCollection().ReplaceOne(
+		ctx,
+		bson.M{"_id": haltResumeId},
+		resume,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+func (s *Storage) GetHaltResume(ctx context.Context) (*HaltResume, error) {
+	defer s.metrics.observeStorage("GetHaltResume", time.Now())
+
+	document := s.getHaltsCollection().FindOne(ctx, bson.M{"_id": haltResumeId})
+	if document.Err() == mongo.ErrNoDocuments {
+		return nil, nil
+	} else if document.Err() != nil {
+		return nil, document.Err()
+	}
+
+	resume := &HaltResume{}
+	if err := document.Decode(resume); err != nil {
+		return nil, err
+	}
+
+	return resume, nil
+}
+
+// SuspendTradingSymbols suspends every symbol that is not suspended yet with a
+// single update, symbols already suspended keep their reason.
+func (s *Storage) SuspendTradingSymbols(ctx context.Context, reason string) error {
+	defer s.metrics.observeStorage("SuspendTradingSymbols", time.Now())
+
+	_, err := s.getSymbolsCollection().UpdateMany(
+		ctx,
+		bson.M{"status": bson.M{"$ne": pb.TradingSymbol_SUSPENDED}},
+		bson.M{
+			"$set": bson.M{"status": pb.TradingSymbol_SUSPENDED, "suspend_reason": reason},
+			"$inc": bson.M{"version": 1},
+		},
+	)
 	return err
 }
 
+func (s *Storage) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx, readpref.Primary())
+}
+
+func (s *Storage) Close(ctx context.Context) error {
+	return s.client.Disconnect(ctx)
+}
+
 func (s *Storage) getSymbolsCollection() *mongo.Collection {
 	return s.client.Database(s.dbName).Collection(symbolsCollection)
 }
@@ -154,19 +1202,104 @@ func (s *Storage) getDealsCollection() *mongo.Collection {
 	return s.client.Database(s.dbName).Collection(dealsCollection)
 }
 
-func (s *Storage) Init() error {
-	ctx := context.Background()
+func (s *Storage) getSubscriptionsCollection() *mongo.Collection {
+	return s.client.Database(s.dbName).Collection(subscriptionsCollection)
+}
+
+func (s *Storage) getHaltsCollection() *mongo.Collection {
+	return s.client.Database(s.dbName).Collection(haltsCollection)
+}
 
-	_ = s.getSymbolsCollection().Drop(ctx)
-	_ = s.SaveTradingSymbol(ctx, &TradingSymbol{"adausdt", pb.TradingSymbol_ACTIVE, 55, 100})
-	_ = s.SaveTradingSymbol(ctx, &TradingSymbol{"linkusdt", pb.TradingSymbol_ACTIVE, 66, 100})
-	_ = s.SaveTradingSymbol(ctx, &TradingSymbol{"zilusdt", pb.TradingSymbol_ACTIVE, 33, 100})
-	_ = s.SaveTradingSymbol(ctx, &TradingSymbol{"ltcusdt", pb.TradingSymbol_ACTIVE, 22, 100})
+func (s *Storage) getClosedDealsCollection() *mongo.Collection {
+	return s.client.Database(s.dbName).Collection(closedDealsCollection)
+}
 
-	_ = s.getDealsCollection().Drop(ctx)
-	_ = s.SaveDeal(ctx, &Deal{"adausdt-1657483456", "adausdt", time.Now(), 0.01, 361, -12, -2, DealPrediction{-3, 2}})
-	_ = s.SaveDeal(ctx, &Deal{"adausdt-1630958723", "adausdt", time.Now(), 0.04, 734, 15, 2, DealPrediction{-5, 7}})
-	_ = s.SaveDeal(ctx, &Deal{"linkusdt-3492445345", "linkusdt", time.Now(), 0.05, 154, 7, 5, DealPrediction{-15, 3}})
+func (s *Storage) getSchedulesCollection() *mongo.Collection {
+	return s.client.Database(s.dbName).Collection(schedulesCollection)
+}
 
+func (s *Storage) getSymbolConfigsCollection() *mongo.Collection {
+	return s.client.Database(s.dbName).Collection(symbolConfigsCollection)
+}
+
+func (s *Storage) getIdempotencyCollection() *mongo.Collection {
+	return s.client.Database(s.dbName).Collection(idempotencyCollection)
+}
+
+func (s *Storage) getBalancesCollection() *mongo.Collection {
+	return s.client.Database(s.dbName).Collection(balancesCollection)
+}
+
+func (s *Storage) getApprovalsCollection() *mongo.Collection {
+	return s.client.Database(s.dbName).Collection(approvalsCollection)
+}
+
+// LoadFixtures inserts the fixtures into collections that are still empty,
+// collections that already have documents are left untouched.
+func (s *Storage) LoadFixtures(ctx context.Context, fixtures *Fixtures) error {
+	defer s.metrics.observeStorage("LoadFixtures", time.Now())
+
+	symbols, err := fixtures.tradingSymbols()
+	if err != nil {
+		return err
+	}
+
+	symbolDocs := make([]interface{}, 0, len(symbols))
+	configDocs := make([]interface{}, 0, len(symbols))
+	for _, symbol := range symbols {
+		symbolDocs = append(symbolDocs, symbol)
+		configDocs = append(configDocs, &symbolConfigVersion{
+			Id:           fmt.Sprintf("%s:%d", symbol.Symbol, symbol.Config.Version),
+			Symbol:       symbol.Symbol,
+			SymbolConfig: symbol.Config,
+		})
+	}
+	if err := seedCollection(ctx, s.getSymbolsCollection(), symbolDocs); err != nil {
+		return err
+	}
+	if err := seedCollection(ctx, s.getSymbolConfigsCollection(), configDocs); err != nil {
+		return err
+	}
+
+	deals := fixtures.deals()
+	dealDocs := make([]interface{}, 0, len(deals))
+	for _, deal := range deals {
+		dealDocs = append(dealDocs, deal)
+	}
+	return seedCollection(ctx, s.getDealsCollection(), dealDocs)
+}
+
+// Drop removes all trading data. The collections are emptied instead of
+// dropped, so that the indexes of the migrations stay in place.
+func (s *Storage) Drop(ctx context.Context) error {
+	defer s.metrics.observeStorage("Drop", time.Now())
+
+	for _, collection := range []*mongo.Collection{
+		s.getSymbolsCollection(),
+		s.getDealsCollection(),
+		s.getClosedDealsCollection(),
+		s.getSymbolConfigsCollection(),
+	} {
+		if _, err := collection.DeleteMany(ctx, bson.M{}); err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+func seedCollection(ctx context.Context, collection *mongo.Collection, docs []interface{}) error {
+	if len(docs) == 0 {
+		return nil
+	}
+
+	count, err := collection.CountDocuments(ctx, bson.M{}, options.Count().SetLimit(1))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	_, err = collection.InsertMany(ctx, docs)
+	return err
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// timeframes are the candle periods of the exchange.
+var timeframes = []string{"1min", "5min", "15min", "30min", "60min", "4hour", "1day"}
+
+// defaultSymbolConfig is the strategy of symbols prepared without a config.
+func defaultSymbolConfig() SymbolConfig {
+	return SymbolConfig{
+		Timeframe:   "15min",
+		EntryDelta:  decimal.NewFromInt(1),
+		StopPercent: decimal.NewFromInt(-2),
+		MaxPercent:  decimal.NewFromInt(3),
+		MaxDeals:    1,
+		OrderSize:   decimal.NewFromInt(10),
+	}
+}
+
+// mergeSymbolConfig overrides the config with the set fields of the request
+// and validates the result. The version is left as it is.
+func mergeSymbolConfig(config SymbolConfig, req *pb.SymbolConfig) (SymbolConfig, error) {
+	if req == nil {
+		return config, nil
+	}
+
+	if req.Timeframe != "" {
+		config.Timeframe = req.Timeframe
+	}
+	if req.MaxConcurrentDeals != 0 {
+		config.MaxDeals = req.MaxConcurrentDeals
+	}
+
+	for _, field := range []struct {
+		value  string
+		target *decimal.Decimal
+	}{
+		{req.EntryDeltaPercent, &config.EntryDelta},
+		{req.StopPercent, &config.StopPercent},
+		{req.MaxPercent, &config.MaxPercent},
+		{req.OrderSize, &config.OrderSize},
+	} {
+		if field.value == "" {
+			continue
+		}
+		d, err := decimalFromPb(field.value, 0)
+		if err != nil {
+			return config, err
+		}
+		*field.target = d
+	}
+
+	return config, validateSymbolConfig(config)
+}
+
+func validateSymbolConfig(config SymbolConfig) error {
+	known := false
+	for _, timeframe := range timeframes {
+		known = known || timeframe == config.Timeframe
+	}
+
+	switch {
+	case !known:
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("unknown timeframe '%s'", config.Timeframe))
+	case !config.EntryDelta.IsPositive():
+		return status.Error(codes.InvalidArgument, "entry delta must be positive")
+	case !config.StopPercent.IsNegative():
+		return status.Error(codes.InvalidArgument, "stop percent must be negative")
+	case !config.MaxPercent.IsPositive():
+		return status.Error(codes.InvalidArgument, "max percent must be positive")
+	case config.MaxDeals <= 0:
+		return status.Error(codes.InvalidArgument, "max concurrent deals must be positive")
+	case !config.OrderSize.IsPositive():
+		return status.Error(codes.InvalidArgument, "order size must be positive")
+	}
+	return nil
+}
+
+// newSymbolConfigVersion stamps the config as the next version.
+func newSymbolConfigVersion(config SymbolConfig, version int32, userId int64) SymbolConfig {
+	config.Version = version
+	config.UpdatedBy = userId
+	config.UpdatedAt = time.Now()
+	return config
+}
+
+func symbolConfigToPb(config *SymbolConfig) *pb.SymbolConfig {
+	result := &pb.SymbolConfig{
+		Version:            config.Version,
+		Timeframe:          config.Timeframe,
+		EntryDeltaPercent:  config.EntryDelta.String(),
+		StopPercent:        config.StopPercent.String(),
+		MaxPercent:         config.MaxPercent.String(),
+		MaxConcurrentDeals: config.MaxDeals,
+		OrderSize:          config.OrderSize.String(),
+		UpdatedBy:          config.UpdatedBy,
+	}
+	if !config.UpdatedAt.IsZero() {
+		result.UpdatedAt = timestamppb.New(config.UpdatedAt)
+	}
+	return result
+}
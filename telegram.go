@@ -0,0 +1,641 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// TelegramClient is a minimal client of the Telegram Bot API. The base URL is
+// configurable, so that the bot can run against a local fake server.
+type TelegramClient struct {
+	baseUrl string
+	token   string
+	client  *http.Client
+}
+
+type telegramUpdate struct {
+	UpdateId      int64                  `json:"update_id"`
+	Message       *telegramMessage       `json:"message"`
+	CallbackQuery *telegramCallbackQuery `json:"callback_query"`
+}
+
+type telegramMessage struct {
+	MessageId int64        `json:"message_id"`
+	From      telegramUser `json:"from"`
+	Chat      telegramChat `json:"chat"`
+	Text      string       `json:"text"`
+}
+
+type telegramCallbackQuery struct {
+	Id      string           `json:"id"`
+	From    telegramUser     `json:"from"`
+	Message *telegramMessage `json:"message"`
+	Data    string           `json:"data"`
+}
+
+type telegramUser struct {
+	Id int64 `json:"id"`
+}
+
+type telegramChat struct {
+	Id int64 `json:"id"`
+}
+
+type telegramInlineKeyboard struct {
+	InlineKeyboard [][]telegramInlineButton `json:"inline_keyboard"`
+}
+
+type telegramInlineButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"`
+}
+
+const telegramPollTimeout = 30 * time.Second
+
+func NewTelegramClient(baseUrl, token string) *TelegramClient {
+	return &TelegramClient{
+		baseUrl: strings.TrimRight(baseUrl, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: telegramPollTimeout + 10*time.Second},
+	}
+}
+
+func (c *TelegramClient) GetUpdates(ctx context.Context, offset int64) ([]telegramUpdate, error) {
+	var updates []telegramUpdate
+	err := c.call(ctx, "getUpdates", map[string]interface{}{
+		"offset":          offset,
+		"timeout":         int(telegramPollTimeout.Seconds()),
+		"allowed_updates": []string{"message", "callback_query"},
+	}, &updates)
+	return updates, err
+}
+
+func (c *TelegramClient) SendMessage(ctx context.Context, chatId int64, text string, keyboard *telegramInlineKeyboard) error {
+	params := map[string]interface{}{
+		"chat_id": chatId,
+		"text":    text,
+	}
+	if keyboard != nil {
+		params["reply_markup"] = keyboard
+	}
+	return c.call(ctx, "sendMessage", params, nil)
+}
+
+func (c *TelegramClient) EditMessageText(ctx context.Context, chatId, messageId int64, text string) error {
+	return c.call(ctx, "editMessageText", map[string]interface{}{
+		"chat_id":    chatId,
+		"message_id": messageId,
+		"text":       text,
+	}, nil)
+}
+
+func (c *TelegramClient) AnswerCallbackQuery(ctx context.Context, id, text string) error {
+	return c.call(ctx, "answerCallbackQuery", map[string]interface{}{
+		"callback_query_id": id,
+		"text":              text,
+	}, nil)
+}
+
+func (c *TelegramClient) call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseUrl+"/bot"+c.token+"/"+method, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var envelope struct {
+		Ok          bool            `json:"ok"`
+		Description string          `json:"description"`
+		Result      json.RawMessage `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return err
+	}
+	if !envelope.Ok {
+		return errors.New(fmt.Sprintf("telegram %s: %s", method, envelope.Description))
+	}
+	if result != nil {
+		return json.Unmarshal(envelope.Result, result)
+	}
+	return nil
+}
+
+// TelegramBot maps operator commands to the Server methods. Telegram user ids
+// are passed as the user ids of the requests, so the bot is authorized the same
+// way as any other client.
+type TelegramBot struct {
+	logger       *zap.SugaredLogger
+	client       *TelegramClient
+	server       pb.GandalfServer
+	interceptors []grpc.UnaryServerInterceptor
+
+	mu      sync.Mutex
+	pending map[string]*telegramPendingAction
+}
+
+// telegramPendingAction is a destructive command waiting for confirmation by
+// the user who sent it.
+type telegramPendingAction struct {
+	userId    int64
+	expiresAt time.Time
+	run       func(ctx context.Context) (string, error)
+}
+
+const (
+	telegramConfirmTTL = 2 * time.Minute
+	telegramHelp       = `/symbols - list trading symbols
+/prepare <symbol> [paper] - prepare a symbol for trading
+/start <symbol> - start trading a symbol
+/suspend <symbol> - suspend trading a symbol
+/resume <symbol> - resume trading a symbol
+/stop <symbol> - stop trading a symbol and remove it
+/balances [quote] - show balances
+/limits - show limits
+/setlimit <symbol> <limit> - set the limit of a symbol
+/deals - list open deals
+/close all|<deal id>... - close deals
+/halt [close] [reason] - suspend all symbols and block new deals, close also closes all deals
+/unhalt - restore the symbols suspended by /halt
+/approvals - list pending approvals
+/approve <approval id> - approve and run the call of another operator
+/reject <approval id> [reason] - reject a pending approval`
+)
+
+func NewTelegramBot(
+	logger *zap.SugaredLogger,
+	client *TelegramClient,
+	server pb.GandalfServer,
+	interceptors []grpc.UnaryServerInterceptor,
+) *TelegramBot {
+	return &TelegramBot{
+		logger:       logger,
+		client:       client,
+		server:       server,
+		interceptors: interceptors,
+		pending:      make(map[string]*telegramPendingAction),
+	}
+}
+
+// Run polls updates until ctx is done.
+func (b *TelegramBot) Run(ctx context.Context) {
+	var offset int64
+	for {
+		updates, err := b.client.GetUpdates(ctx, offset)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			b.logger.Errorf("cannot get telegram updates: %v", err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+			continue
+		}
+
+		for _, update := range updates {
+			offset = update.UpdateId + 1
+			switch {
+			case update.Message != nil:
+				b.handleMessage(ctx, update.Message)
+			case update.CallbackQuery != nil:
+				b.handleCallback(ctx, update.CallbackQuery)
+			}
+		}
+	}
+}
+
+func (b *TelegramBot) handleMessage(ctx context.Context, message *telegramMessage) {
+	fields := strings.Fields(message.Text)
+	if len(fields) == 0 || !strings.HasPrefix(fields[0], "/") {
+		return
+	}
+
+	command := strings.SplitN(strings.TrimPrefix(fields[0], "/"), "@", 2)[0]
+	args := fields[1:]
+	userId := message.From.Id
+
+	var reply string
+	var err error
+	switch command {
+	case "symbols":
+		reply, err = b.symbols(ctx, userId)
+	case "prepare":
+		reply, err = b.prepare(ctx, userId, args)
+	case "start", "suspend", "resume":
+		reply, err = b.setStatus(ctx, userId, command, args)
+	case "stop":
+		if len(args) != 1 {
+			reply = "usage: /stop <symbol>"
+			break
+		}
+		b.askConfirmation(ctx, message, fmt.Sprintf("Stop trading %s and remove it?", args[0]), func(ctx context.Context) (string, error) {
+			return b.setStatus(ctx, userId, command, args)
+		})
+		return
+	case "balances":
+		reply, err = b.balances(ctx, userId, args)
+	case "limits":
+		reply, err = b.limits(ctx, userId)
+	case "setlimit":
+		reply, err = b.setLimit(ctx, userId, args)
+	case "deals":
+		reply, err = b.deals(ctx, userId)
+	case "close":
+		if len(args) == 0 {
+			reply = "usage: /close all|<deal id>..."
+			break
+		}
+		question := fmt.Sprintf("Close deals %s?", strings.Join(args, ", "))
+		if args[0] == "all" {
+			question = "Close ALL open deals?"
+		}
+		b.askConfirmation(ctx, message, question, func(ctx context.Context) (string, error) {
+			return b.closeDeals(ctx, userId, args)
+		})
+		return
+	case "halt":
+		question := "HALT all trading?"
+		if len(args) > 0 && args[0] == "close" {
+			question = "HALT all trading and close ALL open deals?"
+		}
+		b.askConfirmation(ctx, message, question, func(ctx context.Context) (string, error) {
+			return b.halt(ctx, userId, args)
+		})
+		return
+	case "unhalt":
+		reply, err = b.unhalt(ctx, userId)
+	case "approvals":
+		reply, err = b.approvals(ctx, userId)
+	case "approve":
+		if len(args) != 1 {
+			reply = "usage: /approve <approval id>"
+			break
+		}
+		b.askConfirmation(ctx, message, fmt.Sprintf("Approve and run %s?", args[0]), func(ctx context.Context) (string, error) {
+			return b.approve(ctx, userId, args[0])
+		})
+		return
+	case "reject":
+		reply, err = b.reject(ctx, userId, args)
+	default:
+		reply = telegramHelp
+	}
+
+	if err != nil {
+		reply = "Error: " + status.Convert(err).Message()
+	}
+	b.send(ctx, message.Chat.Id, reply, nil)
+}
+
+func (b *TelegramBot) handleCallback(ctx context.Context, query *telegramCallbackQuery) {
+	parts := strings.SplitN(query.Data, ":", 2)
+	if len(parts) != 2 || query.Message == nil {
+		return
+	}
+
+	b.mu.Lock()
+	action, ok := b.pending[parts[1]]
+	if ok && action.userId == query.From.Id {
+		delete(b.pending, parts[1])
+	}
+	b.mu.Unlock()
+
+	var reply string
+	switch {
+	case !ok || time.Now().After(action.expiresAt):
+		reply = "This confirmation has expired."
+	case action.userId != query.From.Id:
+		if err := b.client.AnswerCallbackQuery(ctx, query.Id, "Only the sender of the command can confirm it."); err != nil {
+			b.logger.Errorf("cannot answer telegram callback: %v", err)
+		}
+		return
+	case parts[0] == "cancel":
+		reply = "Cancelled."
+	default:
+		result, err := action.run(ctx)
+		if err != nil {
+			result = "Error: " + status.Convert(err).Message()
+		}
+		reply = result
+	}
+
+	if err := b.client.AnswerCallbackQuery(ctx, query.Id, ""); err != nil {
+		b.logger.Errorf("cannot answer telegram callback: %v", err)
+	}
+	if err := b.client.EditMessageText(ctx, query.Message.Chat.Id, query.Message.MessageId, reply); err != nil {
+		b.logger.Errorf("cannot edit telegram message: %v", err)
+	}
+}
+
+func (b *TelegramBot) askConfirmation(
+	ctx context.Context,
+	message *telegramMessage,
+	question string,
+	run func(ctx context.Context) (string, error),
+) {
+	token := make([]byte, 8)
+	_, _ = rand.Read(token)
+	id := hex.EncodeToString(token)
+
+	now := time.Now()
+	b.mu.Lock()
+	for key, action := range b.pending {
+		if now.After(action.expiresAt) {
+			delete(b.pending, key)
+		}
+	}
+	b.pending[id] = &telegramPendingAction{message.From.Id, now.Add(telegramConfirmTTL), run}
+	b.mu.Unlock()
+
+	b.send(ctx, message.Chat.Id, question, &telegramInlineKeyboard{
+		InlineKeyboard: [][]telegramInlineButton{{
+			{Text: "Confirm", CallbackData: "confirm:" + id},
+			{Text: "Cancel", CallbackData: "cancel:" + id},
+		}},
+	})
+}
+
+func (b *TelegramBot) send(ctx context.Context, chatId int64, text string, keyboard *telegramInlineKeyboard) {
+	if err := b.client.SendMessage(ctx, chatId, text, keyboard); err != nil {
+		b.logger.Errorf("cannot send telegram message: %v", err)
+	}
+}
+
+func (b *TelegramBot) invoke(ctx context.Context, rpc string, req proto.Message) (proto.Message, error) {
+	return invokeServer(ctx, b.server, b.interceptors, rpc, req)
+}
+
+func (b *TelegramBot) symbols(ctx context.Context, userId int64) (string, error) {
+	resp, err := b.invoke(ctx, "GetTradingSymbols", &pb.TradingSymbolsRequest{UserId: userId})
+	if err != nil {
+		return "", err
+	}
+
+	symbols := resp.(*pb.TradingSymbolsResponse).Symbols
+	if len(symbols) == 0 {
+		return "No symbols.", nil
+	}
+
+	lines := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		line := fmt.Sprintf("%s %s", symbol.Symbol, symbol.Status)
+		if symbol.Paper {
+			line += " (paper)"
+		}
+		if symbol.SuspendReason != "" {
+			line += ": " + symbol.SuspendReason
+		}
+		lines = append(lines, line)
+	}
+	if resp.(*pb.TradingSymbolsResponse).NextPageToken != "" {
+		lines = append(lines, "…")
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func (b *TelegramBot) prepare(ctx context.Context, userId int64, args []string) (string, error) {
+	if len(args) < 1 || len(args) > 2 || len(args) == 2 && args[1] != "paper" {
+		return "usage: /prepare <symbol> [paper]", nil
+	}
+
+	_, err := b.invoke(ctx, "SymbolTradingPrepare", &pb.SymbolRequest{
+		UserId: userId,
+		Symbol: args[0],
+		Paper:  len(args) == 2,
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s is prepared.", args[0]), nil
+}
+
+func (b *TelegramBot) setStatus(ctx context.Context, userId int64, command string, args []string) (string, error) {
+	if len(args) != 1 {
+		return fmt.Sprintf("usage: /%s <symbol>", command), nil
+	}
+
+	rpc := map[string]string{
+		"start":   "SymbolTradingStart",
+		"stop":    "SymbolTradingStop",
+		"suspend": "SymbolTradingSuspend",
+		"resume":  "SymbolTradingResume",
+	}[command]
+	if _, err := b.invoke(ctx, rpc, &pb.SymbolRequest{UserId: userId, Symbol: args[0]}); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s: %s done.", args[0], command), nil
+}
+
+func (b *TelegramBot) balances(ctx context.Context, userId int64, args []string) (string, error) {
+	req := &pb.SymbolBalancesRequest{UserId: userId}
+	if len(args) > 0 {
+		req.QuoteCurrency = args[0]
+	}
+
+	resp, err := b.invoke(ctx, "GetSymbolBalances", req)
+	if err != nil {
+		return "", err
+	}
+
+	balances := resp.(*pb.SymbolBalancesResponse)
+	line := func(balance *pb.SymbolBalance) string {
+		if balance.QuoteValueDecimal == "" {
+			return fmt.Sprintf("%s %s = ? %s", balance.Symbol, balance.AmountDecimal, balances.QuoteCurrency)
+		}
+		return fmt.Sprintf("%s %s = %s %s", balance.Symbol, balance.AmountDecimal, balance.QuoteValueDecimal, balances.QuoteCurrency)
+	}
+	var lines []string
+	for _, balance := range balances.Balances {
+		lines = append(lines, line(balance))
+	}
+	lines = append(lines, fmt.Sprintf("Total: %s %s", balances.TotalDecimal, balances.QuoteCurrency))
+	if len(balances.PaperBalances) > 0 {
+		lines = append(lines, "", "Paper:")
+		for _, balance := range balances.PaperBalances {
+			lines = append(lines, line(balance))
+		}
+		lines = append(lines, fmt.Sprintf("Total: %s %s", balances.PaperTotalDecimal, balances.QuoteCurrency))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func (b *TelegramBot) limits(ctx context.Context, userId int64) (string, error) {
+	resp, err := b.invoke(ctx, "GetSymbolLimits", &pb.GetSymbolLimitsRequest{UserId: userId})
+	if err != nil {
+		return "", err
+	}
+
+	limits := resp.(*pb.SymbolLimitsResponse).Limits
+	if len(limits) == 0 {
+		return "No limits.", nil
+	}
+
+	lines := make([]string, 0, len(limits))
+	for _, limit := range limits {
+		line := fmt.Sprintf("%s %s", limit.Symbol, limit.LimitDecimal)
+		if limit.MaxDrawdownDecimal != "" && limit.MaxDrawdownDecimal != "0" {
+			line += fmt.Sprintf(", max drawdown %s", limit.MaxDrawdownDecimal)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func (b *TelegramBot) setLimit(ctx context.Context, userId int64, args []string) (string, error) {
+	if len(args) != 2 {
+		return "usage: /setlimit <symbol> <limit>", nil
+	}
+
+	_, err := b.invoke(ctx, "SetSymbolLimits", &pb.SetSymbolLimitsRequest{
+		UserId: userId,
+		Limits: []*pb.SymbolLimit{{Symbol: args[0], LimitDecimal: args[1]}},
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Limit of %s is %s.", args[0], args[1]), nil
+}
+
+func (b *TelegramBot) deals(ctx context.Context, userId int64) (string, error) {
+	resp, err := b.invoke(ctx, "GetActiveDeals", &pb.DealsRequest{UserId: userId, All: true})
+	if err != nil {
+		return "", err
+	}
+
+	deals := resp.(*pb.DealsResponse).Deals
+	if len(deals) == 0 {
+		return "No open deals.", nil
+	}
+
+	lines := make([]string, 0, len(deals))
+	for _, deal := range deals {
+		line := fmt.Sprintf("%s %s %s (%s%%)", deal.DealId, deal.AmountCurrencyDecimal, deal.DeltaAmountDecimal, deal.DeltaPercentDecimal)
+		if deal.Paper {
+			line += " paper"
+		}
+		lines = append(lines, line)
+	}
+	// a chat message can't hold more than the first page anyway
+	if resp.(*pb.DealsResponse).NextPageToken != "" {
+		lines = append(lines, "…")
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func (b *TelegramBot) closeDeals(ctx context.Context, userId int64, args []string) (string, error) {
+	req := &pb.DealsRequest{UserId: userId}
+	if args[0] == "all" {
+		req.All = true
+	} else {
+		req.DealIds = args
+	}
+
+	if _, err := b.invoke(ctx, "CloseDeals", req); err != nil {
+		return "", err
+	}
+	return "Deals closed.", nil
+}
+
+func (b *TelegramBot) halt(ctx context.Context, userId int64, args []string) (string, error) {
+	req := &pb.EmergencyHaltRequest{UserId: userId}
+	if len(args) > 0 && args[0] == "close" {
+		req.CloseDeals = true
+		args = args[1:]
+	}
+	req.Reason = strings.Join(args, " ")
+
+	if _, err := b.invoke(ctx, "EmergencyHalt", req); err != nil {
+		return "", err
+	}
+	return "Trading is halted.", nil
+}
+
+func (b *TelegramBot) unhalt(ctx context.Context, userId int64) (string, error) {
+	if _, err := b.invoke(ctx, "EmergencyResume", &pb.EmptyRequest{UserId: userId}); err != nil {
+		return "", err
+	}
+	return "Trading is resumed.", nil
+}
+
+func (b *TelegramBot) approvals(ctx context.Context, userId int64) (string, error) {
+	resp, err := b.invoke(ctx, "ListPendingApprovals", &pb.EmptyRequest{UserId: userId})
+	if err != nil {
+		return "", err
+	}
+
+	approvals := resp.(*pb.ApprovalsResponse).Approvals
+	if len(approvals) == 0 {
+		return "No pending approvals.", nil
+	}
+
+	lines := make([]string, 0, len(approvals))
+	for _, approval := range approvals {
+		lines = append(lines, fmt.Sprintf(
+			"%s %s %s by %d until %s",
+			approval.ApprovalId,
+			approval.Method,
+			approval.Request,
+			approval.RequestedBy,
+			approval.ExpiresAt.AsTime().UTC().Format(time.RFC3339),
+		))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func (b *TelegramBot) approve(ctx context.Context, userId int64, approvalId string) (string, error) {
+	resp, err := b.invoke(ctx, "Approve", &pb.ApprovalRequest{UserId: userId, ApprovalId: approvalId})
+	if err != nil {
+		return "", err
+	}
+
+	approval := resp.(*pb.ApprovalResponse).Approval
+	reply := fmt.Sprintf("Approval %s of %s is %s.", approval.ApprovalId, approval.Method, approval.Status)
+	if n := len(approval.Events); n > 0 && approval.Events[n-1].Message != "" {
+		reply += " " + approval.Events[n-1].Message
+	}
+	return reply, nil
+}
+
+func (b *TelegramBot) reject(ctx context.Context, userId int64, args []string) (string, error) {
+	if len(args) == 0 {
+		return "usage: /reject <approval id> [reason]", nil
+	}
+
+	_, err := b.invoke(ctx, "Reject", &pb.ApprovalRequest{
+		UserId:     userId,
+		ApprovalId: args[0],
+		Reason:     strings.Join(args[1:], " "),
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Approval %s is rejected.", args[0]), nil
+}
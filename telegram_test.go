@@ -0,0 +1,404 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/mikevel2955/gandalf/pb"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const fakeTelegramToken = "test-token"
+
+type fakeTelegramCall struct {
+	method string
+	params map[string]interface{}
+}
+
+// fakeTelegram serves the Bot API methods the bot uses. getUpdates returns
+// the queued batches one by one, and calls onIdle once they are exhausted.
+type fakeTelegram struct {
+	mu      sync.Mutex
+	updates [][]telegramUpdate
+	calls   []fakeTelegramCall
+	onIdle  func()
+}
+
+func newFakeTelegram(t *testing.T) (*fakeTelegram, *TelegramClient) {
+	t.Helper()
+
+	fake := &fakeTelegram{}
+	server := httptest.NewServer(http.HandlerFunc(fake.serve))
+	t.Cleanup(server.Close)
+
+	return fake, NewTelegramClient(server.URL+"/", fakeTelegramToken)
+}
+
+func (f *fakeTelegram) serve(w http.ResponseWriter, r *http.Request) {
+	prefix := "/bot" + fakeTelegramToken + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"ok":false,"description":"Unauthorized"}`))
+		return
+	}
+	method := strings.TrimPrefix(r.URL.Path, prefix)
+
+	params := make(map[string]interface{})
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"ok":false,"description":"Bad Request"}`))
+		return
+	}
+
+	var result interface{} = true
+	f.mu.Lock()
+	f.calls = append(f.calls, fakeTelegramCall{method, params})
+	var onIdle func()
+	if method == "getUpdates" {
+		updates := []telegramUpdate{}
+		if len(f.updates) > 0 {
+			updates, f.updates = f.updates[0], f.updates[1:]
+		} else {
+			onIdle = f.onIdle
+		}
+		result = updates
+	}
+	f.mu.Unlock()
+
+	if onIdle != nil {
+		onIdle()
+	}
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "result": result})
+}
+
+// takeCalls returns the calls of the method made since the last take.
+func (f *fakeTelegram) takeCalls(method string) []fakeTelegramCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var calls, rest []fakeTelegramCall
+	for _, call := range f.calls {
+		if call.method == method {
+			calls = append(calls, call)
+		} else {
+			rest = append(rest, call)
+		}
+	}
+	f.calls = rest
+	return calls
+}
+
+// lastText returns the text of the only message sent since the last take.
+func (f *fakeTelegram) lastText(t *testing.T) string {
+	t.Helper()
+
+	calls := f.takeCalls("sendMessage")
+	if len(calls) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(calls))
+	}
+	return calls[0].params["text"].(string)
+}
+
+// fakeBotServer records the rpcs the bot calls.
+type fakeBotServer struct {
+	*pb.UnimplementedGandalfServer
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func (s *fakeBotServer) record(format string, args ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, fmt.Sprintf(format, args...))
+}
+
+func (s *fakeBotServer) takeCalls() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	calls := s.calls
+	s.calls = nil
+	return calls
+}
+
+func (s *fakeBotServer) SymbolTradingSuspend(_ context.Context, req *pb.SymbolRequest) (*pb.EmptyResponse, error) {
+	s.record("SymbolTradingSuspend %s by %d", req.Symbol, req.UserId)
+	return &pb.EmptyResponse{}, nil
+}
+
+func (s *fakeBotServer) SymbolTradingStop(_ context.Context, req *pb.SymbolRequest) (*pb.EmptyResponse, error) {
+	s.record("SymbolTradingStop %s by %d", req.Symbol, req.UserId)
+	return &pb.EmptyResponse{}, nil
+}
+
+func (s *fakeBotServer) SymbolTradingPrepare(_ context.Context, req *pb.SymbolRequest) (*pb.EmptyResponse, error) {
+	s.record("SymbolTradingPrepare %s paper=%t by %d", req.Symbol, req.Paper, req.UserId)
+	return &pb.EmptyResponse{}, nil
+}
+
+func (s *fakeBotServer) SetSymbolLimits(_ context.Context, req *pb.SetSymbolLimitsRequest) (*pb.EmptyResponse, error) {
+	for _, limit := range req.Limits {
+		s.record("SetSymbolLimits %s=%s by %d", limit.Symbol, limit.LimitDecimal, req.UserId)
+	}
+	return &pb.EmptyResponse{}, nil
+}
+
+func (s *fakeBotServer) CloseDeals(_ context.Context, req *pb.DealsRequest) (*pb.EmptyResponse, error) {
+	s.record("CloseDeals all=%t ids=%v by %d", req.All, req.DealIds, req.UserId)
+	return &pb.EmptyResponse{}, nil
+}
+
+func (s *fakeBotServer) EmergencyHalt(_ context.Context, req *pb.EmergencyHaltRequest) (*pb.EmptyResponse, error) {
+	s.record("EmergencyHalt close=%t reason=%q by %d", req.CloseDeals, req.Reason, req.UserId)
+	return &pb.EmptyResponse{}, nil
+}
+
+func (s *fakeBotServer) EmergencyResume(_ context.Context, req *pb.EmptyRequest) (*pb.EmptyResponse, error) {
+	return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("user %d is not an operator", req.UserId))
+}
+
+func newTestTelegramBot(t *testing.T) (*TelegramBot, *fakeTelegram, *fakeBotServer) {
+	t.Helper()
+
+	fake, client := newFakeTelegram(t)
+	server := &fakeBotServer{UnimplementedGandalfServer: &pb.UnimplementedGandalfServer{}}
+	return NewTelegramBot(zap.NewNop().Sugar(), client, server, nil), fake, server
+}
+
+func telegramText(userId int64, text string) *telegramMessage {
+	return &telegramMessage{MessageId: 1, From: telegramUser{userId}, Chat: telegramChat{userId}, Text: text}
+}
+
+// confirmationId returns the id of the pending action behind the buttons of
+// the confirmation question.
+func confirmationId(t *testing.T, call fakeTelegramCall) string {
+	t.Helper()
+
+	markup, _ := call.params["reply_markup"].(map[string]interface{})
+	rows, _ := markup["inline_keyboard"].([]interface{})
+	if len(rows) != 1 {
+		t.Fatalf("confirmation has no buttons: %v", call.params)
+	}
+	buttons := rows[0].([]interface{})
+	confirm := buttons[0].(map[string]interface{})["callback_data"].(string)
+	cancel := buttons[1].(map[string]interface{})["callback_data"].(string)
+
+	id := strings.TrimPrefix(confirm, "confirm:")
+	if confirm == id || cancel != "cancel:"+id {
+		t.Fatalf("unexpected buttons %q and %q", confirm, cancel)
+	}
+	return id
+}
+
+// askedConfirmation returns the id of the only confirmation asked since the
+// last take.
+func askedConfirmation(t *testing.T, fake *fakeTelegram) string {
+	t.Helper()
+
+	calls := fake.takeCalls("sendMessage")
+	if len(calls) != 1 {
+		t.Fatalf("sent %d messages, want a confirmation", len(calls))
+	}
+	return confirmationId(t, calls[0])
+}
+
+func TestTelegramBotCommands(t *testing.T) {
+	ctx := context.Background()
+	bot, fake, server := newTestTelegramBot(t)
+
+	tests := []struct {
+		text  string
+		call  string
+		reply string
+	}{
+		{"/suspend adausdt", "SymbolTradingSuspend adausdt by 7", "adausdt: suspend done."},
+		{"/suspend@gandalf_bot  adausdt", "SymbolTradingSuspend adausdt by 7", "adausdt: suspend done."},
+		{"/prepare dotusdt paper", "SymbolTradingPrepare dotusdt paper=true by 7", "dotusdt is prepared."},
+		{"/setlimit adausdt 250.5", "SetSymbolLimits adausdt=250.5 by 7", "Limit of adausdt is 250.5."},
+		{"/suspend", "", "usage: /suspend <symbol>"},
+		{"/prepare dotusdt real", "", "usage: /prepare <symbol> [paper]"},
+		{"/unhalt", "", "Error: user 7 is not an operator"},
+		{"/whatever", "", telegramHelp},
+	}
+
+	for _, test := range tests {
+		bot.handleMessage(ctx, telegramText(7, test.text))
+
+		calls := server.takeCalls()
+		if test.call == "" && len(calls) != 0 || test.call != "" && (len(calls) != 1 || calls[0] != test.call) {
+			t.Errorf("%q called %v, want %q", test.text, calls, test.call)
+		}
+		if reply := fake.lastText(t); test.reply != "" && reply != test.reply {
+			t.Errorf("%q replied %q, want %q", test.text, reply, test.reply)
+		}
+	}
+
+	// plain text is not a command
+	bot.handleMessage(ctx, telegramText(7, "suspend adausdt"))
+	if calls := fake.takeCalls("sendMessage"); len(calls) != 0 {
+		t.Errorf("plain text got %d replies", len(calls))
+	}
+}
+
+func TestTelegramBotConfirmation(t *testing.T) {
+	ctx := context.Background()
+	bot, fake, server := newTestTelegramBot(t)
+
+	// a destructive command only asks
+	bot.handleMessage(ctx, telegramText(7, "/close all"))
+	id := askedConfirmation(t, fake)
+	if calls := server.takeCalls(); len(calls) != 0 {
+		t.Fatalf("close ran before the confirmation: %v", calls)
+	}
+
+	// another user can't confirm it and it stays pending
+	bot.handleCallback(ctx, &telegramCallbackQuery{
+		Id:      "q1",
+		From:    telegramUser{8},
+		Message: telegramText(7, ""),
+		Data:    "confirm:" + id,
+	})
+	if calls := server.takeCalls(); len(calls) != 0 {
+		t.Fatalf("another user confirmed the close: %v", calls)
+	}
+	answers := fake.takeCalls("answerCallbackQuery")
+	if len(answers) != 1 || answers[0].params["text"] != "Only the sender of the command can confirm it." {
+		t.Errorf("another user got %v", answers)
+	}
+	if edits := fake.takeCalls("editMessageText"); len(edits) != 0 {
+		t.Errorf("another user edited the question: %v", edits)
+	}
+
+	// the sender confirms it once
+	confirm := &telegramCallbackQuery{Id: "q2", From: telegramUser{7}, Message: telegramText(7, ""), Data: "confirm:" + id}
+	bot.handleCallback(ctx, confirm)
+	if calls := server.takeCalls(); len(calls) != 1 || calls[0] != "CloseDeals all=true ids=[] by 7" {
+		t.Errorf("confirmation called %v", calls)
+	}
+	edits := fake.takeCalls("editMessageText")
+	if len(edits) != 1 || edits[0].params["text"] != "Deals closed." {
+		t.Errorf("confirmation edited %v", edits)
+	}
+
+	bot.handleCallback(ctx, confirm)
+	if calls := server.takeCalls(); len(calls) != 0 {
+		t.Errorf("second confirmation called %v", calls)
+	}
+	edits = fake.takeCalls("editMessageText")
+	if len(edits) != 1 || edits[0].params["text"] != "This confirmation has expired." {
+		t.Errorf("second confirmation edited %v", edits)
+	}
+
+	// cancel drops the action
+	bot.handleMessage(ctx, telegramText(7, "/stop adausdt"))
+	id = askedConfirmation(t, fake)
+	bot.handleCallback(ctx, &telegramCallbackQuery{Id: "q3", From: telegramUser{7}, Message: telegramText(7, ""), Data: "cancel:" + id})
+	bot.handleCallback(ctx, &telegramCallbackQuery{Id: "q4", From: telegramUser{7}, Message: telegramText(7, ""), Data: "confirm:" + id})
+	if calls := server.takeCalls(); len(calls) != 0 {
+		t.Errorf("cancelled stop called %v", calls)
+	}
+	edits = fake.takeCalls("editMessageText")
+	if len(edits) != 2 || edits[0].params["text"] != "Cancelled." || edits[1].params["text"] != "This confirmation has expired." {
+		t.Errorf("cancel edited %v", edits)
+	}
+}
+
+func TestTelegramBotConfirmationExpiry(t *testing.T) {
+	ctx := context.Background()
+	bot, fake, server := newTestTelegramBot(t)
+
+	bot.handleMessage(ctx, telegramText(7, "/halt close market crash"))
+	expired := askedConfirmation(t, fake)
+	bot.handleMessage(ctx, telegramText(7, "/stop adausdt"))
+	fresh := askedConfirmation(t, fake)
+
+	bot.mu.Lock()
+	bot.pending[expired].expiresAt = time.Now().Add(-time.Second)
+	bot.mu.Unlock()
+
+	bot.handleCallback(ctx, &telegramCallbackQuery{Id: "q1", From: telegramUser{7}, Message: telegramText(7, ""), Data: "confirm:" + expired})
+	if calls := server.takeCalls(); len(calls) != 0 {
+		t.Errorf("expired confirmation called %v", calls)
+	}
+	edits := fake.takeCalls("editMessageText")
+	if len(edits) != 1 || edits[0].params["text"] != "This confirmation has expired." {
+		t.Errorf("expired confirmation edited %v", edits)
+	}
+
+	// a new question sweeps the expired actions and keeps the others
+	bot.mu.Lock()
+	bot.pending[fresh].expiresAt = time.Now().Add(-time.Second)
+	bot.mu.Unlock()
+	bot.handleMessage(ctx, telegramText(7, "/halt"))
+	last := askedConfirmation(t, fake)
+
+	bot.mu.Lock()
+	_, freshKept := bot.pending[fresh]
+	_, lastKept := bot.pending[last]
+	bot.mu.Unlock()
+	if freshKept || !lastKept {
+		t.Errorf("pending actions after the sweep: expired kept %t, new kept %t", freshKept, lastKept)
+	}
+
+	bot.handleCallback(ctx, &telegramCallbackQuery{Id: "q2", From: telegramUser{7}, Message: telegramText(7, ""), Data: "confirm:" + last})
+	if calls := server.takeCalls(); len(calls) != 1 || calls[0] != `EmergencyHalt close=false reason="" by 7` {
+		t.Errorf("halt confirmation called %v", calls)
+	}
+}
+
+func TestTelegramBotRun(t *testing.T) {
+	bot, fake, server := newTestTelegramBot(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fake.updates = [][]telegramUpdate{
+		{{UpdateId: 10, Message: telegramText(7, "/suspend adausdt")}},
+		{{UpdateId: 11, Message: telegramText(7, "/halt close market crash")}},
+	}
+	fake.onIdle = cancel
+
+	done := make(chan struct{})
+	go func() {
+		bot.Run(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("bot didn't stop")
+	}
+
+	polls := fake.takeCalls("getUpdates")
+	if len(polls) != 3 {
+		t.Fatalf("polled %d times, want 3", len(polls))
+	}
+	for i, offset := range []float64{0, 11, 12} {
+		if polls[i].params["offset"] != offset {
+			t.Errorf("poll %d has offset %v, want %v", i, polls[i].params["offset"], offset)
+		}
+	}
+
+	if calls := server.takeCalls(); len(calls) != 1 || calls[0] != "SymbolTradingSuspend adausdt by 7" {
+		t.Errorf("updates called %v", calls)
+	}
+	messages := fake.takeCalls("sendMessage")
+	if len(messages) != 2 || messages[0].params["text"] != "adausdt: suspend done." {
+		t.Fatalf("sent %v", messages)
+	}
+	if messages[1].params["text"] != "HALT all trading and close ALL open deals?" {
+		t.Errorf("halt asked %q", messages[1].params["text"])
+	}
+	if chat := messages[1].params["chat_id"]; chat != float64(7) {
+		t.Errorf("halt asked in chat %v, want 7", chat)
+	}
+	confirmationId(t, messages[1])
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelCodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const tracerName = "github.com/mikevel2955/gandalf"
+
+// NewTracerProvider exports spans over OTLP/HTTP when a collector is configured
+// through the standard OTEL_EXPORTER_OTLP_* variables, otherwise spans are
+// written as JSON to the trace file or to stdout if the file is not set.
+func NewTracerProvider(ctx context.Context, traceFile string) (*sdktrace.TracerProvider, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+
+	if otlpConfigured() {
+		exporter, err = otlptracehttp.New(ctx)
+	} else {
+		var w io.Writer = os.Stdout
+		if traceFile != "" {
+			if w, err = os.OpenFile(traceFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+				return nil, err
+			}
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(w))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(AppName),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider, nil
+}
+
+func otlpConfigured() bool {
+	for _, name := range []string{"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"} {
+		if os.Getenv(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// TracingInterceptor starts a server span for every call, continuing the
+// trace passed by the client in the request metadata.
+func TracingInterceptor() grpc.UnaryServerInterceptor {
+	tracer := otel.Tracer(tracerName)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+		ctx, span := tracer.Start(ctx, info.FullMethod,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.RPCSystemKey.String("grpc")),
+		)
+		defer span.End()
+
+		if r, ok := req.(userRequest); ok {
+			span.SetAttributes(attribute.Int64("gandalf.user_id", r.GetUserId()))
+		}
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int64(int64(code)))
+		if err != nil {
+			span.SetStatus(otelCodes.Error, err.Error())
+		}
+
+		return resp, err
+	}
+}
+
+// TracingStreamInterceptor is the TracingInterceptor of streaming calls, the
+// span lasts until the last message is sent.
+func TracingStreamInterceptor() grpc.StreamServerInterceptor {
+	tracer := otel.Tracer(tracerName)
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		md, _ := metadata.FromIncomingContext(ss.Context())
+		ctx := otel.GetTextMapPropagator().Extract(ss.Context(), metadataCarrier(md))
+
+		ctx, span := tracer.Start(ctx, info.FullMethod,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.RPCSystemKey.String("grpc")),
+		)
+		defer span.End()
+
+		stream := &wrappedStream{
+			ServerStream: ss,
+			ctx:          ctx,
+			onRecv: func(m interface{}) error {
+				if r, ok := m.(userRequest); ok {
+					span.SetAttributes(attribute.Int64("gandalf.user_id", r.GetUserId()))
+				}
+				return nil
+			},
+		}
+
+		err := handler(srv, stream)
+
+		code := status.Code(err)
+		span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int64(int64(code)))
+		if err != nil {
+			span.SetStatus(otelCodes.Error, err.Error())
+		}
+
+		return err
+	}
+}
+
+// metadataCarrier adapts grpc metadata to the propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// NewTracingCommandMonitor creates a client span for every Mongo command as a
+// child of the span in the context the storage was called with.
+func NewTracingCommandMonitor() *event.CommandMonitor {
+	tracer := otel.Tracer(tracerName)
+
+	var mu sync.Mutex
+	spans := make(map[int64]trace.Span)
+
+	finish := func(requestId int64, failure string) {
+		mu.Lock()
+		span, ok := spans[requestId]
+		delete(spans, requestId)
+		mu.Unlock()
+
+		if !ok {
+			return
+		}
+		if failure != "" {
+			span.SetStatus(otelCodes.Error, failure)
+		}
+		span.End()
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+			attributes := []attribute.KeyValue{
+				semconv.DBSystemMongoDB,
+				semconv.DBNameKey.String(e.DatabaseName),
+				semconv.DBOperationKey.String(e.CommandName),
+			}
+			if collection, ok := e.Command.Lookup(e.CommandName).StringValueOK(); ok {
+				attributes = append(attributes, semconv.DBMongoDBCollectionKey.String(collection))
+			}
+
+			_, span := tracer.Start(ctx, "mongo."+e.CommandName,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attributes...),
+			)
+			mu.Lock()
+			spans[e.RequestID] = span
+			mu.Unlock()
+		},
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			finish(e.RequestID, "")
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			finish(e.RequestID, e.Failure)
+		},
+	}
+}